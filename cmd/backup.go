@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"avito-task/internal/backup"
+	"avito-task/internal/domain"
+	"avito-task/internal/usecases"
+)
+
+var (
+	errBackupUsage  = errors.New("usage: backup <file>")
+	errRestoreUsage = errors.New("usage: restore <file>")
+)
+
+// runBackup executes `backup` subcommand: writes teams, users, PRs, reviewers and the audit log
+// read from one snapshot to the archive. It is written to a temporary file next to the target
+// and renamed at the end, so a failed backup does not leave a partial archive behind.
+func runBackup(ctx context.Context, exportSvc usecases.ExportService, args []string) (err error) {
+	if len(args) != 1 {
+		return errBackupUsage
+	}
+
+	path := args[0]
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	w, err := backup.NewWriter(f, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err = exportSvc.Export(ctx, domain.BackupEntities, domain.ExportFilter{}, w.Write); err != nil {
+		return err
+	}
+
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Rename(f.Name(), path); err != nil {
+		return err
+	}
+
+	slog.Info("backup is written",
+		slog.String("file", path),
+		slog.Int("format_version", backup.Version),
+		slog.Any("counts", w.Counts()),
+	)
+
+	return nil
+}
+
+// runRestore executes `restore` subcommand: checks the archive and restores it into the empty
+// database in one transaction, nothing is restored if any record is invalid.
+func runRestore(ctx context.Context, restoreSvc usecases.RestoreService, args []string) error {
+	if len(args) != 1 {
+		return errRestoreUsage
+	}
+
+	path := args[0]
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	r, err := backup.NewReader(f)
+	if err != nil {
+		return err
+	}
+
+	counts, err := restoreSvc.Restore(ctx, r.Next)
+	if err != nil {
+		return fmt.Errorf("nothing is restored: %w", err)
+	}
+
+	slog.Info("backup is restored",
+		slog.String("file", path),
+		slog.Time("created_at", r.CreatedAt),
+		slog.Any("counts", counts),
+	)
+
+	return nil
+}
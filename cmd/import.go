@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"avito-task/internal/api/http/types"
+	"avito-task/internal/usecases"
+)
+
+var (
+	errImportUsage    = errors.New("usage: import [-dry-run] [-format csv|ndjson] [-report path] <file>")
+	errImportRejected = errors.New("import is rejected, no PRs were created: see problems in the report")
+)
+
+// runImport executes `import` subcommand: reads PRs from the file (its format is detected by
+// extension unless given), imports them and writes the report to out or to -report file,
+// since logs are written to stdout too.
+func runImport(
+	ctx context.Context,
+	importSvc usecases.ImportService,
+	maxRecords int,
+	args []string,
+	out io.Writer,
+) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "only check records and print the report")
+	formatName := fs.String("format", "", "file format: csv or ndjson (detected by extension if empty)")
+	reportPath := fs.String("report", "", "file to write the report to instead of stdout")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errImportUsage, err)
+	}
+
+	if fs.NArg() != 1 {
+		return errImportUsage
+	}
+
+	path := fs.Arg(0)
+	format := types.ImportFormat(*formatName)
+
+	if len(format) == 0 {
+		var err error
+
+		if format, err = types.ImportFormatFromPath(path); err != nil {
+			return fmt.Errorf("%w: %w", errImportUsage, err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	records, err := types.ReadImportRecords(f, format, maxRecords)
+	if err != nil {
+		return err
+	}
+
+	report, err := importSvc.ImportPullRequests(ctx, records, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	if len(*reportPath) > 0 {
+		rf, err := os.Create(*reportPath)
+		if err != nil {
+			return err
+		}
+
+		defer rf.Close()
+		out = rf
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	if err = enc.Encode(types.CreateImportReport(report)); err != nil {
+		return err
+	}
+
+	slog.Info("import finished",
+		slog.String("file", path),
+		slog.Bool("dry_run", report.DryRun),
+		slog.Bool("applied", report.Applied),
+		slog.Int("invalid", report.Invalid),
+	)
+
+	if !report.DryRun && !report.Applied {
+		return errImportRejected
+	}
+
+	return nil
+}
@@ -1,19 +1,34 @@
 package main
 
 import (
+	"avito-task/internal/api/graphql"
 	"avito-task/internal/config"
+	"avito-task/internal/metrics"
 	"avito-task/internal/usecases/service"
+	"avito-task/docs"
+	"avito-task/migrations"
 	pkgConfig "avito-task/pkg/config"
 	"avito-task/pkg/database/postgres"
+	"avito-task/pkg/health"
+	"avito-task/pkg/jwks"
+	"avito-task/pkg/logger"
 	"avito-task/pkg/shutdown"
+	"avito-task/pkg/tracing"
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"log/slog"
+	"os"
+	"path"
 	"time"
 
+	grpcapp "avito-task/internal/app/grpc"
 	httpapp "avito-task/internal/app/http"
-	repo "avito-task/internal/repository/postgres"
+	pkgMiddleware "avito-task/pkg/http/middleware"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -22,23 +37,165 @@ func main() {
 	var cfg config.Config
 	pkgConfig.MustLoadConfig(appFlags.ConfigPath, &cfg)
 
-	log.Printf("[INFO] Service is starting")
+	appLogger, err := logger.New(cfg.LogCfg, os.Stdout)
+	if err != nil {
+		log.Fatalf("[ERROR] Failed to create logger: %s", err.Error())
+	}
+
+	slog.SetDefault(appLogger)
+	slog.Info("service is starting")
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.TracingCfg)
+	if err != nil {
+		fatal("failed to set up tracing", err)
+	}
+
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			slog.Error("failed to flush traces", logger.Err(err))
+		}
+	}()
+
+	var st *storage
+
+	switch cfg.StorageCfg.Driver {
+	case config.StorageMemory:
+		if appFlags.Command != "" {
+			fatal("commands require postgres storage", fmt.Errorf("%q", appFlags.Command))
+		}
+
+		st = newMemoryStorage()
+		slog.Warn("using in-memory storage, data will be lost on restart")
+
+	case config.StoragePostgres:
+		pool, err := postgres.NewPostgresPool(cfg.PostgresCfg)
+		if err != nil {
+			fatal("failed to connect PostgreSQL", err)
+		}
+
+		defer pool.Close()
+		slog.Info("connected to PostgreSQL successfully")
+
+		migrator, err := postgres.NewMigrator(pool, migrations.FS)
+		if err != nil {
+			fatal("failed to load migrations", err)
+		}
+
+		switch appFlags.Command {
+		case "":
+		case "migrate":
+			if err = runMigrate(context.Background(), migrator, appFlags.Args); err != nil {
+				fatal("migrate command failed", err)
+			}
+
+			return
+		case "import", "backup", "restore":
+			// Run on top of services, see below.
+		default:
+			fatal("unknown command", fmt.Errorf("%q", appFlags.Command))
+		}
+
+		if cfg.SvcCfg.MigrateOnStartup {
+			applied, err := migrator.Up(context.Background())
+			if err != nil {
+				fatal("failed to apply migrations", err)
+			}
+
+			slog.Info("database schema is up to date", slog.Any("applied", applied), slog.Int64("version", migrator.Latest()))
+		}
+
+		st = newPostgresStorage(pool, migrator.Latest(), cfg.StorageCfg.TxRetry)
+
+	default:
+		fatal("unknown storage driver", fmt.Errorf("%q", cfg.StorageCfg.Driver))
+	}
+
+	teamSvc := service.NewTeamService(st.txManager, st.teamRepo, st.userRepo, st.prRepo, st.auditRepo)
+	userSvc := service.NewUserService(st.txManager, st.userRepo, st.prRepo, st.auditRepo)
+	picker := service.NewSeededReviewerPicker(cfg.SvcCfg.RandomSeed)
+
+	prSvc := service.NewPullRequestService(st.txManager, st.prRepo, st.userRepo, st.teamRepo, st.auditRepo, picker)
+	authSvc := service.NewAuthService(st.txManager, st.keyRepo, st.auditRepo)
+	auditSvc := service.NewAuditService(st.auditRepo)
+	idemSvc := service.NewIdempotencyService(st.idemRepo, cfg.SvcCfg.IdempotencyTTL, cfg.SvcCfg.IdempotencyLease)
+	batchSvc := service.NewBatchService(st.txManager, prSvc, userSvc)
+	exportSvc := service.NewExportService(st.txManager, st.teamRepo, st.exportRepo)
+
+	switch appFlags.Command {
+	case "import":
+		if err = runImport(context.Background(), prSvc, cfg.SvcCfg.ImportMaxRecords, appFlags.Args, os.Stdout); err != nil {
+			fatal("import command failed", err)
+		}
+
+		return
+	case "backup":
+		if err = runBackup(context.Background(), exportSvc, appFlags.Args); err != nil {
+			fatal("backup command failed", err)
+		}
+
+		return
+	case "restore":
+		restoreSvc := service.NewRestoreService(st.txManager, st.restoreRepo)
+
+		if err = runRestore(context.Background(), restoreSvc, appFlags.Args); err != nil {
+			fatal("restore command failed", err)
+		}
+
+		return
+	}
 
-	pool, err := postgres.NewPostgresPool(cfg.PostgresCfg)
+	graphQLSchema, err := graphql.NewSchema(teamSvc, userSvc, prSvc)
 	if err != nil {
-		log.Fatalf("[ERROR] Failed to connect PostgreSQL: %s", err.Error())
+		fatal("failed to build GraphQL schema", err)
 	}
 
-	defer pool.Close()
-	log.Printf("[INFO] Connected to PostgreSQL successfully")
+	var validator, validatorV2 *pkgMiddleware.OpenAPIValidator
 
-	teamRepo := repo.NewTeamRepo(pool)
-	userRepo := repo.NewUserRepo(pool)
-	prRepo := repo.NewPullRequestRepo(pool)
+	if cfg.SvcCfg.ValidateRequests {
+		validator, err = pkgMiddleware.NewOpenAPIValidator(docs.OpenAPI, cfg.PathCfg.APIPath, cfg.SvcCfg.ValidateResponses)
+		if err != nil {
+			fatal("failed to load OpenAPI document", err)
+		}
 
-	teamSvc := service.NewTeamService(pool, teamRepo, userRepo, prRepo)
-	userSvc := service.NewUserService(userRepo, prRepo)
-	prSvc := service.NewPullRequestService(pool, prRepo, userRepo)
+		validatorV2, err = pkgMiddleware.NewOpenAPIValidator(
+			docs.OpenAPIV2,
+			path.Join(cfg.PathCfg.APIPath, cfg.PathCfg.V2),
+			cfg.SvcCfg.ValidateResponses,
+		)
+		if err != nil {
+			fatal("failed to load OpenAPI document of API v2", err)
+		}
+	}
+
+	if cfg.AuthCfg.BootstrapKey != "" {
+		if err = authSvc.BootstrapKey(context.Background(), cfg.AuthCfg.BootstrapKey); err != nil {
+			fatal("failed to register bootstrap API key", err)
+		}
+	}
+
+	var verifier *jwks.Verifier
+
+	if cfg.AuthCfg.JWT.Enabled {
+		if verifier, err = jwks.NewVerifier(cfg.AuthCfg.JWT.Verifier); err != nil {
+			fatal("failed to load JWKS", err)
+		}
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		metrics.NewDomainCollector(st.prRepo, cfg.SvcCfg.MetricsTimeout),
+	)
+	registry.MustRegister(st.collectors...)
+
+	checker := health.NewChecker(cfg.SvcCfg.HealthTimeout)
+	for name, check := range st.checks {
+		checker.Register(name, check)
+	}
 
 	httpApp := httpapp.New(
 		cfg.HTTPCfg,
@@ -47,9 +204,28 @@ func main() {
 		teamSvc,
 		userSvc,
 		prSvc,
+		authSvc,
+		auditSvc,
+		idemSvc,
+		batchSvc,
+		prSvc,
+		exportSvc,
+		graphQLSchema,
+		validator,
+		validatorV2,
+		cfg.AuthCfg,
+		verifier,
+		registry,
+		checker,
 	)
 
-	log.Printf("[INFO] All services were created successfully")
+	var grpcApp *grpcapp.App
+
+	if cfg.GRPCCfg.Enabled {
+		grpcApp = grpcapp.New(cfg.GRPCCfg, teamSvc, userSvc, prSvc, authSvc, cfg.AuthCfg, verifier)
+	}
+
+	slog.Info("all services were created successfully")
 
 	g, ctx := errgroup.WithContext(context.Background())
 
@@ -61,9 +237,30 @@ func main() {
 		return httpApp.Run()
 	})
 
+	if grpcApp != nil {
+		g.Go(func() error {
+			return grpcApp.Run()
+		})
+
+		g.Go(func() error {
+			<-ctx.Done()
+
+			stopCtx, cancel := context.WithTimeout(context.Background(), cfg.GRPCCfg.StopTimeout)
+			defer cancel()
+
+			grpcApp.Stop(stopCtx)
+			return nil
+		})
+	}
+
+	g.Go(func() error {
+		purgeIdempotencyKeys(ctx, idemSvc, cfg.SvcCfg.IdempotencyPurgeInterval)
+		return nil
+	})
+
 	g.Go(func() error {
 		<-ctx.Done()
-		log.Printf("[INFO] Shutdown signal received, stopping server")
+		slog.Info("shutdown signal received, stopping server")
 
 		const ctxTimeExceed = 3 * time.Second
 
@@ -74,6 +271,33 @@ func main() {
 
 	err = g.Wait()
 	if err != nil && !errors.Is(err, shutdown.ErrOSSignal) {
-		log.Printf("[INFO] Exit reason: %s", err.Error())
+		slog.Info("service stopped", slog.String("reason", err.Error()))
 	}
 }
+
+// purgeIdempotencyKeys periodically deletes expired idempotency keys until ctx is done.
+func purgeIdempotencyKeys(ctx context.Context, idemSvc *service.IdempotencyService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := idemSvc.PurgeExpired(ctx)
+		if err != nil {
+			slog.Error("failed to purge idempotency keys", logger.Err(err))
+			continue
+		}
+
+		slog.Debug("idempotency keys purged", slog.Int64("deleted", deleted))
+	}
+}
+
+func fatal(msg string, err error) {
+	slog.Error(msg, logger.Err(err))
+	os.Exit(1)
+}
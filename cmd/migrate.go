@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"avito-task/pkg/database/postgres"
+)
+
+var errMigrateUsage = errors.New("usage: migrate up | down [steps] | version")
+
+// runMigrate executes `migrate` subcommand: up applies all pending migrations,
+// down reverts given number of latest ones (1 by default), version prints the current one.
+func runMigrate(ctx context.Context, migrator *postgres.Migrator, args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		slog.Info("migrations applied", slog.Any("versions", applied))
+		return err
+
+	case "down":
+		steps := 1
+
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("%w: invalid steps %q", errMigrateUsage, args[1])
+			}
+
+			steps = n
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		slog.Info("migrations reverted", slog.Any("versions", reverted))
+		return err
+
+	case "version":
+		version, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+
+		slog.Info("schema version", slog.Int64("current", version), slog.Int64("latest", migrator.Latest()))
+		return nil
+
+	default:
+		return errMigrateUsage
+	}
+}
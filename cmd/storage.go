@@ -0,0 +1,70 @@
+package main
+
+import (
+	"avito-task/internal/repository"
+	"avito-task/internal/repository/memory"
+	"avito-task/pkg/database/postgres"
+	"avito-task/pkg/health"
+
+	repo "avito-task/internal/repository/postgres"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// storage bundles repositories of the configured backend together with
+// backend-specific health checks and metrics.
+type storage struct {
+	txManager   repository.TxManager
+	teamRepo    repository.TeamRepo
+	userRepo    repository.UserRepo
+	prRepo      repository.PullRequestRepo
+	keyRepo     repository.APIKeyRepo
+	auditRepo   repository.AuditRepo
+	idemRepo    repository.IdempotencyRepo
+	exportRepo  repository.ExportRepo
+	restoreRepo repository.RestoreRepo
+
+	checks     map[string]health.Check
+	collectors []prometheus.Collector
+}
+
+// newPostgresStorage creates storage on top of pool. Transactions aborted because of
+// concurrent ones are run again according to retryCfg.
+func newPostgresStorage(pool *pgxpool.Pool, schemaVersion int64, retryCfg repository.RetryConfig) *storage {
+	return &storage{
+		txManager:   repository.NewRetryTxManager(repo.NewTxManager(pool), retryCfg, postgres.IsRetryable),
+		teamRepo:    repo.NewTeamRepo(pool),
+		userRepo:    repo.NewUserRepo(pool),
+		prRepo:      repo.NewPullRequestRepo(pool),
+		keyRepo:     repo.NewAPIKeyRepo(pool),
+		auditRepo:   repo.NewAuditRepo(pool),
+		idemRepo:    repo.NewIdempotencyRepo(pool),
+		exportRepo:  repo.NewExportRepo(pool),
+		restoreRepo: repo.NewRestoreRepo(pool),
+		checks: map[string]health.Check{
+			"postgres": postgres.PingCheck(pool),
+			"schema":   postgres.SchemaVersionCheck(pool, schemaVersion),
+		},
+		collectors: []prometheus.Collector{
+			postgres.NewPoolCollector(pool),
+		},
+	}
+}
+
+// newMemoryStorage creates empty in-process storage, data is lost on restart.
+func newMemoryStorage() *storage {
+	store := memory.NewStore()
+
+	return &storage{
+		txManager:   store,
+		teamRepo:    memory.NewTeamRepo(store),
+		userRepo:    memory.NewUserRepo(store),
+		prRepo:      memory.NewPullRequestRepo(store),
+		keyRepo:     memory.NewAPIKeyRepo(store),
+		auditRepo:   memory.NewAuditRepo(store),
+		idemRepo:    memory.NewIdempotencyRepo(store),
+		exportRepo:  memory.NewExportRepo(store),
+		restoreRepo: memory.NewRestoreRepo(store),
+	}
+}
@@ -0,0 +1,13 @@
+// Package docs embeds the OpenAPI document so the binary validates requests
+// against the same specification it serves in Swagger UI.
+package docs
+
+import _ "embed"
+
+//go:embed openapi.yaml
+var OpenAPI []byte
+
+// OpenAPIV2 describes the resource-oriented /v2 API.
+//
+//go:embed openapi-v2.yaml
+var OpenAPIV2 []byte
@@ -3,7 +3,10 @@ window.onload = function() {
 
   // the following lines will be replaced by docker/configurator, when it runs in a docker-container
   window.ui = SwaggerUIBundle({
-    url: "./openapi.yaml",
+    urls: [
+      { url: "./openapi.yaml", name: "v1" },
+      { url: "./openapi-v2.yaml", name: "v2" }
+    ],
     dom_id: '#swagger-ui',
     deepLinking: true,
     presets: [
@@ -0,0 +1,66 @@
+package graphql
+
+import (
+	"avito-task/internal/api/http/response"
+	"avito-task/pkg/logger"
+	"avito-task/pkg/requestid"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	pkgErrors "avito-task/pkg/errors"
+)
+
+var (
+	ErrVersionOutOfRange = errors.New("ifVersion must be positive")
+)
+
+// Error is a resolver error with the same code as REST error responses in extensions.
+type Error struct {
+	code      string
+	message   string
+	requestID string
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Extensions() map[string]any {
+	ext := map[string]any{"code": e.code}
+	if len(e.requestID) > 0 {
+		ext["request_id"] = e.requestID
+	}
+
+	return ext
+}
+
+// badRequest converts error of arguments validation.
+func badRequest(ctx context.Context, err error) error {
+	slog.WarnContext(ctx, "graphql field failed", logger.Err(err))
+
+	return &Error{
+		code:      "BAD_REQUEST",
+		message:   pkgErrors.UnwrapAll(err).Error(),
+		requestID: requestid.FromContext(ctx),
+	}
+}
+
+// processError converts usecase error in the same way as REST API does.
+func processError(ctx context.Context, err error) error {
+	codes, public := response.ResolveError(err)
+
+	level := slog.LevelWarn
+	if codes.HTTPCode >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+
+	slog.Log(ctx, level, "graphql field failed", slog.Int("status", codes.HTTPCode), logger.Err(err))
+
+	return &Error{
+		code:      codes.StrCode,
+		message:   public.Error(),
+		requestID: requestid.FromContext(ctx),
+	}
+}
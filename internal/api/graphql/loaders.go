@@ -0,0 +1,50 @@
+package graphql
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/usecases"
+	"avito-task/pkg/dataloader"
+	"context"
+	"time"
+)
+
+const (
+	loaderWait     = 2 * time.Millisecond
+	loaderMaxBatch = 100
+)
+
+// loaders batch lookups made by resolvers of one request, so nested lists
+// (team members, their reviews, PR authors) cost one repository call per level.
+type loaders struct {
+	users   *dataloader.Loader[string, *domain.User]
+	reviews *dataloader.Loader[string, []*domain.PullRequestShort]
+}
+
+type loadersKey struct{}
+
+func newLoaders(userSvc usecases.UserService) *loaders {
+	return &loaders{
+		users: dataloader.New(func(ctx context.Context, ids []string) (map[string]*domain.User, error) {
+			users, err := userSvc.GetUsers(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			res := make(map[string]*domain.User, len(users))
+			for _, u := range users {
+				res[u.ID] = u
+			}
+
+			return res, nil
+		}, loaderWait, loaderMaxBatch),
+		reviews: dataloader.New(userSvc.GetReviews, loaderWait, loaderMaxBatch),
+	}
+}
+
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
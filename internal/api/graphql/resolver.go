@@ -0,0 +1,289 @@
+package graphql
+
+import (
+	"avito-task/internal/api/validation"
+	"avito-task/internal/domain"
+	"avito-task/internal/repository"
+	"avito-task/internal/usecases"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	pkgMiddleware "avito-task/pkg/http/middleware"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+var (
+	managerRoles  = []domain.AccessRole{domain.AccessAdmin, domain.AccessTeamLead}
+	reassignRoles = []domain.AccessRole{domain.AccessAdmin, domain.AccessTeamLead, domain.AccessMember}
+)
+
+type resolver struct {
+	teamSvc usecases.TeamService
+	userSvc usecases.UserService
+	prSvc   usecases.PullRequestService
+}
+
+// requireRoles checks principal of the request for mutations allowed not to everyone,
+// the endpoint itself is open to any authenticated role.
+func requireRoles(ctx context.Context, roles ...domain.AccessRole) error {
+	p, ok := pkgMiddleware.PrincipalFromContext(ctx)
+	if !ok {
+		return processError(ctx, pkgMiddleware.ErrUnauthorized)
+	}
+
+	if !slices.Contains(roles, domain.AccessRole(p.Role)) {
+		return processError(ctx, pkgMiddleware.ErrForbidden)
+	}
+
+	return nil
+}
+
+// Queries ---------------------------------------------------
+
+func (r *resolver) Team(ctx context.Context, args struct{ Name string }) (*teamResolver, error) {
+	team, err := r.teamSvc.GetTeam(ctx, args.Name)
+	if errors.Is(err, repository.ErrTeamNotExists) {
+		return nil, nil
+	} else if err != nil {
+		return nil, processError(ctx, err)
+	}
+
+	return &teamResolver{team: team, teamSvc: r.teamSvc}, nil
+}
+
+func (r *resolver) User(ctx context.Context, args struct{ ID graphql.ID }) (*userResolver, error) {
+	user, err := loadersFromContext(ctx).users.Load(ctx, string(args.ID))
+	if err != nil {
+		return nil, processError(ctx, err)
+	}
+
+	if user == nil {
+		return nil, nil
+	}
+
+	return &userResolver{user: user}, nil
+}
+
+func (r *resolver) PullRequest(ctx context.Context, args struct{ ID graphql.ID }) (*pullRequestResolver, error) {
+	pr, err := r.prSvc.GetPullRequest(ctx, string(args.ID))
+	if errors.Is(err, repository.ErrPRNotExists) {
+		return nil, nil
+	} else if err != nil {
+		return nil, processError(ctx, err)
+	}
+
+	return &pullRequestResolver{pr: pr}, nil
+}
+
+// Mutations -------------------------------------------------
+
+type teamMemberInput struct {
+	ID       graphql.ID
+	Username string
+	IsActive bool
+	Role     *string
+}
+
+type teamInput struct {
+	Name                  string
+	Members               []teamMemberInput
+	RequiredReviewerRoles *[]string
+}
+
+func (r *resolver) CreateTeam(ctx context.Context, args struct{ Input teamInput }) (*teamResolver, error) {
+	const op = "resolver.CreateTeam"
+
+	if err := requireRoles(ctx, domain.AccessAdmin); err != nil {
+		return nil, err
+	}
+
+	var v validation.Validator
+	v.ID("input.name", args.Input.Name)
+
+	team := &domain.Team{Name: args.Input.Name}
+	seen := make(map[graphql.ID]int, len(args.Input.Members))
+
+	for i, m := range args.Input.Members {
+		v.ID(fmt.Sprintf("input.members.%d.id", i), string(m.ID))
+		v.Name(fmt.Sprintf("input.members.%d.username", i), m.Username)
+
+		if first, ok := seen[m.ID]; ok && len(m.ID) > 0 {
+			v.Add(fmt.Sprintf("input.members.%d.id", i), "duplicates input.members.%d", first)
+		} else {
+			seen[m.ID] = i
+		}
+
+		role := domain.RoleMiddle
+		if m.Role != nil {
+			role = userRoleFromEnum(*m.Role)
+		}
+
+		team.Members = append(team.Members, &domain.User{
+			ID:       string(m.ID),
+			Name:     m.Username,
+			IsActive: m.IsActive,
+			Role:     role,
+		})
+	}
+
+	if args.Input.RequiredReviewerRoles != nil {
+		for _, role := range *args.Input.RequiredReviewerRoles {
+			team.RequiredRoles = append(team.RequiredRoles, userRoleFromEnum(role))
+		}
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, badRequest(ctx, fmt.Errorf("%s: %w", op, err))
+	}
+
+	res, err := r.teamSvc.CreateTeam(ctx, team)
+	if err != nil {
+		return nil, processError(ctx, err)
+	}
+
+	return &teamResolver{team: res, teamSvc: r.teamSvc}, nil
+}
+
+func (r *resolver) DeactivateTeam(ctx context.Context, args struct{ Name string }) ([]*userResolver, error) {
+	if err := requireRoles(ctx, managerRoles...); err != nil {
+		return nil, err
+	}
+
+	users, err := r.teamSvc.DeactivateTeam(ctx, args.Name)
+	if err != nil {
+		return nil, processError(ctx, err)
+	}
+
+	return userResolvers(users), nil
+}
+
+func (r *resolver) SetIsActive(ctx context.Context, args struct {
+	UserID   graphql.ID
+	IsActive bool
+}) (*userResolver, error) {
+	if err := requireRoles(ctx, managerRoles...); err != nil {
+		return nil, err
+	}
+
+	user, err := r.userSvc.SetIsActive(ctx, string(args.UserID), args.IsActive)
+	if err != nil {
+		return nil, processError(ctx, err)
+	}
+
+	return &userResolver{user: user}, nil
+}
+
+type pullRequestInput struct {
+	ID       graphql.ID
+	Name     string
+	AuthorID graphql.ID
+}
+
+func (r *resolver) CreatePullRequest(ctx context.Context, args struct{ Input pullRequestInput }) (*pullRequestResolver, error) {
+	const op = "resolver.CreatePullRequest"
+
+	in := args.Input
+
+	var v validation.Validator
+	v.ID("input.id", string(in.ID))
+	v.Name("input.name", in.Name)
+	v.ID("input.authorId", string(in.AuthorID))
+
+	if err := v.Err(); err != nil {
+		return nil, badRequest(ctx, fmt.Errorf("%s: %w", op, err))
+	}
+
+	pr, err := r.prSvc.CreatePullRequest(ctx, &domain.PullRequest{
+		ID:       string(in.ID),
+		Name:     in.Name,
+		AuthorID: string(in.AuthorID),
+	})
+	if err != nil {
+		return nil, processError(ctx, err)
+	}
+
+	return &pullRequestResolver{pr: pr}, nil
+}
+
+// ifVersion converts optional version argument, 0 means any version for usecases.
+func ifVersion(ctx context.Context, op string, v *int32) (int64, error) {
+	if v == nil {
+		return 0, nil
+	}
+
+	if *v <= 0 {
+		return 0, badRequest(ctx, fmt.Errorf("%s: %w", op, ErrVersionOutOfRange))
+	}
+
+	return int64(*v), nil
+}
+
+func (r *resolver) MergePullRequest(ctx context.Context, args struct {
+	ID        graphql.ID
+	IfVersion *int32
+}) (*pullRequestResolver, error) {
+	version, err := ifVersion(ctx, "resolver.MergePullRequest", args.IfVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := r.prSvc.Merge(ctx, string(args.ID), version)
+	if err != nil {
+		return nil, processError(ctx, err)
+	}
+
+	return &pullRequestResolver{pr: pr}, nil
+}
+
+type reassignResult struct {
+	pr         *domain.PullRequest
+	replacedBy string
+}
+
+func (r *resolver) ReassignPullRequest(ctx context.Context, args struct {
+	ID            graphql.ID
+	OldReviewerID graphql.ID
+	IfVersion     *int32
+}) (*reassignResult, error) {
+	if err := requireRoles(ctx, reassignRoles...); err != nil {
+		return nil, err
+	}
+
+	version, err := ifVersion(ctx, "resolver.ReassignPullRequest", args.IfVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	newID, pr, err := r.prSvc.Reassign(ctx, string(args.ID), string(args.OldReviewerID), version)
+	if err != nil {
+		return nil, processError(ctx, err)
+	}
+
+	return &reassignResult{pr: pr, replacedBy: newID}, nil
+}
+
+func (r *reassignResult) PullRequest() *pullRequestResolver {
+	return &pullRequestResolver{pr: r.pr}
+}
+
+func (r *reassignResult) ReplacedByID() graphql.ID {
+	return graphql.ID(r.replacedBy)
+}
+
+func (r *reassignResult) ReplacedBy(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.replacedBy)
+}
+
+// Enums -----------------------------------------------------
+
+func userRoleFromEnum(role string) domain.UserRole {
+	return domain.UserRole(strings.ToLower(role))
+}
+
+func userRoleToEnum(role domain.UserRole) string {
+	return strings.ToUpper(string(role))
+}
@@ -0,0 +1,54 @@
+package graphql
+
+import (
+	"avito-task/internal/usecases"
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+const (
+	maxDepth       = 8
+	maxParallelism = 50
+)
+
+type Schema struct {
+	schema  *graphql.Schema
+	userSvc usecases.UserService
+}
+
+func NewSchema(
+	teamSvc usecases.TeamService,
+	userSvc usecases.UserService,
+	prSvc usecases.PullRequestService,
+) (*Schema, error) {
+	const op = "graphql.NewSchema"
+
+	schema, err := graphql.ParseSchema(schemaSDL, &resolver{
+		teamSvc: teamSvc,
+		userSvc: userSvc,
+		prSvc:   prSvc,
+	},
+		graphql.MaxDepth(maxDepth),
+		graphql.MaxParallelism(maxParallelism),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Schema{
+		schema:  schema,
+		userSvc: userSvc,
+	}, nil
+}
+
+// Exec runs the operation with loaders shared by all its resolvers.
+func (s *Schema) Exec(ctx context.Context, query, operationName string, variables map[string]any) *graphql.Response {
+	ctx = withLoaders(ctx, newLoaders(s.userSvc))
+	return s.schema.Exec(ctx, query, operationName, variables)
+}
@@ -0,0 +1,143 @@
+package graphql_test
+
+import (
+	"avito-task/internal/api/graphql"
+	"avito-task/internal/api/validation"
+	"avito-task/internal/domain"
+	"avito-task/internal/repository/memory"
+	"avito-task/internal/usecases/service"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	pkgMiddleware "avito-task/pkg/http/middleware"
+
+	"github.com/stretchr/testify/require"
+)
+
+// countingUserService counts batch calls made by loaders.
+type countingUserService struct {
+	*service.UserService
+
+	getUsers   atomic.Int32
+	getReviews atomic.Int32
+}
+
+func (s *countingUserService) GetUsers(ctx context.Context, ids []string) ([]*domain.User, error) {
+	s.getUsers.Add(1)
+	return s.UserService.GetUsers(ctx, ids)
+}
+
+func (s *countingUserService) GetReviews(ctx context.Context, ids []string) (map[string][]*domain.PullRequestShort, error) {
+	s.getReviews.Add(1)
+	return s.UserService.GetReviews(ctx, ids)
+}
+
+func newSchema(t *testing.T) (*graphql.Schema, *countingUserService) {
+	t.Helper()
+
+	store := memory.NewStore()
+	teamRepo := memory.NewTeamRepo(store)
+	userRepo := memory.NewUserRepo(store)
+	prRepo := memory.NewPullRequestRepo(store)
+	auditRepo := memory.NewAuditRepo(store)
+
+	teamSvc := service.NewTeamService(store, teamRepo, userRepo, prRepo, auditRepo)
+	userSvc := &countingUserService{UserService: service.NewUserService(store, userRepo, prRepo, auditRepo)}
+	prSvc := service.NewPullRequestService(store, prRepo, userRepo, teamRepo, auditRepo, service.NewSeededReviewerPicker(7))
+
+	ctx := context.Background()
+	team := &domain.Team{Name: "backend"}
+	for _, id := range []string{"u1", "u2", "u3", "u4"} {
+		team.Members = append(team.Members, &domain.User{ID: id, Name: id, IsActive: true, Role: domain.RoleMiddle})
+	}
+
+	_, err := teamSvc.CreateTeam(ctx, team)
+	require.NoError(t, err)
+
+	for _, pr := range []*domain.PullRequest{
+		{ID: "pr-1", Name: "one", AuthorID: "u1"},
+		{ID: "pr-2", Name: "two", AuthorID: "u2"},
+	} {
+		_, err = prSvc.CreatePullRequest(ctx, pr)
+		require.NoError(t, err)
+	}
+
+	schema, err := graphql.NewSchema(teamSvc, userSvc, prSvc)
+	require.NoError(t, err)
+
+	return schema, userSvc
+}
+
+func withRole(role domain.AccessRole) context.Context {
+	return pkgMiddleware.WithPrincipal(context.Background(), &pkgMiddleware.Principal{ID: "p", Role: string(role)})
+}
+
+func TestTeamQueryBatchesNestedLookups(t *testing.T) {
+	schema, userSvc := newSchema(t)
+
+	res := schema.Exec(withRole(domain.AccessMember), `{
+		team(name: "backend") {
+			members { id reviews(status: OPEN) { id author { username } } }
+			stats { users { user { username } openReviewsCount } }
+		}
+	}`, "", nil)
+	require.Empty(t, res.Errors)
+
+	var data struct {
+		Team struct {
+			Members []struct {
+				ID      string
+				Reviews []struct {
+					ID     string
+					Author struct{ Username string }
+				}
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(res.Data, &data))
+	require.Len(t, data.Team.Members, 4)
+
+	reviews := 0
+	for _, m := range data.Team.Members {
+		reviews += len(m.Reviews)
+	}
+	require.Equal(t, 4, reviews)
+
+	require.EqualValues(t, 1, userSvc.getReviews.Load())
+	require.LessOrEqual(t, userSvc.getUsers.Load(), int32(2))
+}
+
+func TestMutationErrors(t *testing.T) {
+	schema, _ := newSchema(t)
+
+	res := schema.Exec(withRole(domain.AccessMember), `mutation {
+		createTeam(input: {name: "frontend", members: []}) { name }
+	}`, "", nil)
+	require.Len(t, res.Errors, 1)
+	require.Equal(t, "FORBIDDEN", res.Errors[0].Extensions["code"])
+
+	res = schema.Exec(withRole(domain.AccessAdmin), `mutation {
+		mergePullRequest(id: "pr-1", ifVersion: 7) { status }
+	}`, "", nil)
+	require.Len(t, res.Errors, 1)
+	require.Equal(t, "PRECONDITION_FAILED", res.Errors[0].Extensions["code"])
+
+	res = schema.Exec(withRole(domain.AccessAdmin), `mutation {
+		mergePullRequest(id: "missing") { status }
+	}`, "", nil)
+	require.Len(t, res.Errors, 1)
+	require.Equal(t, "NOT_FOUND", res.Errors[0].Extensions["code"])
+
+	longID := strings.Repeat("a", validation.MaxIDLength+1)
+
+	res = schema.Exec(withRole(domain.AccessAdmin), `mutation {
+		createPullRequest(input: {id: "`+longID+`", name: "long", authorId: "u 1"}) { id }
+	}`, "", nil)
+	require.Len(t, res.Errors, 1)
+	require.Equal(t, "BAD_REQUEST", res.Errors[0].Extensions["code"])
+	require.Contains(t, res.Errors[0].Message, "input.id: must be at most 100 characters long")
+	require.Contains(t, res.Errors[0].Message, "input.authorId: may contain only")
+}
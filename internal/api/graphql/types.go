@@ -0,0 +1,250 @@
+package graphql
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/usecases"
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+func loadUser(ctx context.Context, id string) (*userResolver, error) {
+	user, err := loadersFromContext(ctx).users.Load(ctx, id)
+	if err != nil {
+		return nil, processError(ctx, err)
+	}
+
+	if user == nil {
+		return nil, nil
+	}
+
+	return &userResolver{user: user}, nil
+}
+
+// Team ------------------------------------------------------
+
+type teamResolver struct {
+	team    *domain.Team
+	teamSvc usecases.TeamService
+}
+
+func (r *teamResolver) Name() string {
+	return r.team.Name
+}
+
+func (r *teamResolver) Members() []*userResolver {
+	for _, u := range r.team.Members {
+		u.TeamName = r.team.Name
+	}
+
+	return userResolvers(r.team.Members)
+}
+
+func (r *teamResolver) RequiredReviewerRoles() []string {
+	roles := make([]string, 0, len(r.team.RequiredRoles))
+	for _, role := range r.team.RequiredRoles {
+		roles = append(roles, userRoleToEnum(role))
+	}
+
+	return roles
+}
+
+func (r *teamResolver) Stats(ctx context.Context) (*teamStatsResolver, error) {
+	stats, err := r.teamSvc.GetTeamStats(ctx, r.team.Name)
+	if err != nil {
+		return nil, processError(ctx, err)
+	}
+
+	return &teamStatsResolver{stats: stats}, nil
+}
+
+type teamStatsResolver struct {
+	stats *domain.TeamStats
+}
+
+func (r *teamStatsResolver) Users() []*userStatsResolver {
+	res := make([]*userStatsResolver, 0, len(r.stats.Users))
+	for _, s := range r.stats.Users {
+		res = append(res, &userStatsResolver{stats: s})
+	}
+
+	return res
+}
+
+func (r *teamStatsResolver) OpenPullRequests() []*pullRequestStatsResolver {
+	res := make([]*pullRequestStatsResolver, 0, len(r.stats.PRs))
+	for _, s := range r.stats.PRs {
+		res = append(res, &pullRequestStatsResolver{stats: s})
+	}
+
+	return res
+}
+
+type userStatsResolver struct {
+	stats *domain.UserStats
+}
+
+func (r *userStatsResolver) UserID() graphql.ID {
+	return graphql.ID(r.stats.ID)
+}
+
+func (r *userStatsResolver) User(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.stats.ID)
+}
+
+func (r *userStatsResolver) OpenReviewsCount() int32 {
+	return int32(r.stats.ReviewsCount)
+}
+
+type pullRequestStatsResolver struct {
+	stats *domain.PullRequestStats
+}
+
+func (r *pullRequestStatsResolver) PullRequestID() graphql.ID {
+	return graphql.ID(r.stats.ID)
+}
+
+func (r *pullRequestStatsResolver) Status() string {
+	return string(r.stats.Status)
+}
+
+func (r *pullRequestStatsResolver) ReviewersCount() int32 {
+	return int32(r.stats.ReviewersCount)
+}
+
+// User ------------------------------------------------------
+
+type userResolver struct {
+	user *domain.User
+}
+
+func userResolvers(users []*domain.User) []*userResolver {
+	res := make([]*userResolver, 0, len(users))
+	for _, u := range users {
+		res = append(res, &userResolver{user: u})
+	}
+
+	return res
+}
+
+func (r *userResolver) ID() graphql.ID {
+	return graphql.ID(r.user.ID)
+}
+
+func (r *userResolver) Username() string {
+	return r.user.Name
+}
+
+func (r *userResolver) TeamName() string {
+	return r.user.TeamName
+}
+
+func (r *userResolver) IsActive() bool {
+	return r.user.IsActive
+}
+
+func (r *userResolver) Role() string {
+	return userRoleToEnum(r.user.Role)
+}
+
+func (r *userResolver) Reviews(ctx context.Context, args struct{ Status *string }) ([]*pullRequestShortResolver, error) {
+	prs, err := loadersFromContext(ctx).reviews.Load(ctx, r.user.ID)
+	if err != nil {
+		return nil, processError(ctx, err)
+	}
+
+	res := make([]*pullRequestShortResolver, 0, len(prs))
+	for _, pr := range prs {
+		if args.Status == nil || string(pr.Status) == *args.Status {
+			res = append(res, &pullRequestShortResolver{pr: pr})
+		}
+	}
+
+	return res, nil
+}
+
+// Pull request ----------------------------------------------
+
+type pullRequestShortResolver struct {
+	pr *domain.PullRequestShort
+}
+
+func (r *pullRequestShortResolver) ID() graphql.ID {
+	return graphql.ID(r.pr.ID)
+}
+
+func (r *pullRequestShortResolver) Name() string {
+	return r.pr.Name
+}
+
+func (r *pullRequestShortResolver) AuthorID() graphql.ID {
+	return graphql.ID(r.pr.AuthorID)
+}
+
+func (r *pullRequestShortResolver) Author(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.pr.AuthorID)
+}
+
+func (r *pullRequestShortResolver) Status() string {
+	return string(r.pr.Status)
+}
+
+type pullRequestResolver struct {
+	pr *domain.PullRequest
+}
+
+func (r *pullRequestResolver) ID() graphql.ID {
+	return graphql.ID(r.pr.ID)
+}
+
+func (r *pullRequestResolver) Name() string {
+	return r.pr.Name
+}
+
+func (r *pullRequestResolver) AuthorID() graphql.ID {
+	return graphql.ID(r.pr.AuthorID)
+}
+
+func (r *pullRequestResolver) Author(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.pr.AuthorID)
+}
+
+func (r *pullRequestResolver) Status() string {
+	return string(r.pr.Status)
+}
+
+func (r *pullRequestResolver) Reviewers(ctx context.Context) ([]*userResolver, error) {
+	users, err := loadersFromContext(ctx).users.LoadMany(ctx, r.pr.Reviewers)
+	if err != nil {
+		return nil, processError(ctx, err)
+	}
+
+	res := make([]*userResolver, 0, len(users))
+	for _, u := range users {
+		if u != nil {
+			res = append(res, &userResolver{user: u})
+		}
+	}
+
+	return res, nil
+}
+
+func (r *pullRequestResolver) CreatedAt() *graphql.Time {
+	if r.pr.CreatedAt == nil {
+		return nil
+	}
+
+	return &graphql.Time{Time: *r.pr.CreatedAt}
+}
+
+func (r *pullRequestResolver) MergedAt() *graphql.Time {
+	if r.pr.MergedAt == nil {
+		return nil
+	}
+
+	return &graphql.Time{Time: *r.pr.MergedAt}
+}
+
+func (r *pullRequestResolver) Version() int32 {
+	return int32(r.pr.Version)
+}
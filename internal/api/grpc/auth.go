@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/usecases"
+	"context"
+	"strings"
+
+	"avito-task/pkg/grpc/interceptor"
+	pkgMiddleware "avito-task/pkg/http/middleware"
+	pb "avito-task/pkg/pb/reviewer/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const authorizationKey = "authorization"
+
+// CredentialsAuthenticator checks credentials independently of transport,
+// it is implemented by HTTP AuthHandler so both APIs accept the same keys and tokens.
+type CredentialsAuthenticator interface {
+	AuthenticateCredentials(ctx context.Context, authorization, rawKey string) (*pkgMiddleware.Principal, error)
+}
+
+// MetadataAuthenticator reads credentials from authorization and API key metadata
+// (the header name of the HTTP API in lower case).
+func MetadataAuthenticator(authn CredentialsAuthenticator, apiKeyHeader string) interceptor.Authenticator {
+	apiKeyKey := strings.ToLower(apiKeyHeader)
+
+	return func(ctx context.Context, md metadata.MD) (*pkgMiddleware.Principal, error) {
+		return authn.AuthenticateCredentials(ctx, firstValue(md, authorizationKey), firstValue(md, apiKeyKey))
+	}
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// MethodRoles lists roles allowed to call every method, the same as for the HTTP routes.
+func MethodRoles() map[string][]string {
+	anyRole := []domain.AccessRole{
+		domain.AccessAdmin, domain.AccessTeamLead, domain.AccessMember, domain.AccessIntegration,
+	}
+	managerRoles := []domain.AccessRole{domain.AccessAdmin, domain.AccessTeamLead}
+
+	methods := map[string][]domain.AccessRole{
+		pb.TeamService_CreateTeam_FullMethodName:     {domain.AccessAdmin},
+		pb.TeamService_GetTeam_FullMethodName:        anyRole,
+		pb.TeamService_GetTeamStats_FullMethodName:   anyRole,
+		pb.TeamService_DeactivateTeam_FullMethodName: managerRoles,
+
+		pb.UserService_SetIsActive_FullMethodName: managerRoles,
+		pb.UserService_GetReview_FullMethodName:   anyRole,
+
+		pb.PullRequestService_CreatePullRequest_FullMethodName: anyRole,
+		pb.PullRequestService_Merge_FullMethodName:             anyRole,
+		pb.PullRequestService_Reassign_FullMethodName: {
+			domain.AccessAdmin, domain.AccessTeamLead, domain.AccessMember,
+		},
+	}
+
+	res := make(map[string][]string, len(methods))
+	for method, roles := range methods {
+		for _, r := range roles {
+			res[method] = append(res[method], string(r))
+		}
+	}
+
+	return res
+}
+
+// ActorInterceptor passes authenticated principal to the usecase layer as operation actor,
+// it must go after interceptor.RequireRoles.
+func ActorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if p, ok := pkgMiddleware.PrincipalFromContext(ctx); ok {
+			ctx = usecases.WithActor(ctx, &domain.Actor{
+				ID:       p.ID,
+				Role:     domain.AccessRole(p.Role),
+				TeamName: p.TeamName,
+			})
+		}
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,200 @@
+package grpc
+
+import (
+	"avito-task/internal/api/validation"
+	"avito-task/internal/domain"
+	"fmt"
+
+	pb "avito-task/pkg/pb/reviewer/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	userRolesToPB = map[domain.UserRole]pb.UserRole{
+		domain.RoleJunior: pb.UserRole_USER_ROLE_JUNIOR,
+		domain.RoleMiddle: pb.UserRole_USER_ROLE_MIDDLE,
+		domain.RoleSenior: pb.UserRole_USER_ROLE_SENIOR,
+		domain.RoleLead:   pb.UserRole_USER_ROLE_LEAD,
+	}
+	userRolesFromPB = map[pb.UserRole]domain.UserRole{
+		pb.UserRole_USER_ROLE_JUNIOR: domain.RoleJunior,
+		pb.UserRole_USER_ROLE_MIDDLE: domain.RoleMiddle,
+		pb.UserRole_USER_ROLE_SENIOR: domain.RoleSenior,
+		pb.UserRole_USER_ROLE_LEAD:   domain.RoleLead,
+	}
+	prStatusesToPB = map[domain.PRStatus]pb.PullRequestStatus{
+		domain.PROpen:   pb.PullRequestStatus_PULL_REQUEST_STATUS_OPEN,
+		domain.PRMerged: pb.PullRequestStatus_PULL_REQUEST_STATUS_MERGED,
+	}
+)
+
+// Requests --------------------------------------------------
+
+func teamFromPB(team *pb.Team) (*domain.Team, error) {
+	const op = "teamFromPB"
+
+	var v validation.Validator
+
+	if team == nil {
+		v.Add("team", "is required")
+		return nil, fmt.Errorf("%s: %w", op, v.Err())
+	}
+
+	v.ID("team_name", team.GetTeamName())
+
+	res := &domain.Team{
+		Name:    team.GetTeamName(),
+		Members: make([]*domain.User, 0, len(team.GetMembers())),
+	}
+
+	seen := make(map[string]int, len(team.GetMembers()))
+
+	for i, u := range team.GetMembers() {
+		v.ID(fmt.Sprintf("members.%d.user_id", i), u.GetUserId())
+		v.Name(fmt.Sprintf("members.%d.username", i), u.GetUsername())
+
+		if first, ok := seen[u.GetUserId()]; ok && len(u.GetUserId()) > 0 {
+			v.Add(fmt.Sprintf("members.%d.user_id", i), "duplicates members.%d", first)
+		} else {
+			seen[u.GetUserId()] = i
+		}
+
+		role := domain.RoleMiddle
+		if u.GetRole() != pb.UserRole_USER_ROLE_UNSPECIFIED {
+			var ok bool
+			if role, ok = userRolesFromPB[u.GetRole()]; !ok {
+				v.Add(fmt.Sprintf("members.%d.role", i), "%s", ErrInvalidRole)
+			}
+		}
+
+		res.Members = append(res.Members, &domain.User{
+			ID:       u.GetUserId(),
+			Name:     u.GetUsername(),
+			IsActive: u.GetIsActive(),
+			Role:     role,
+		})
+	}
+
+	for i, r := range team.GetRequiredReviewerRoles() {
+		role, ok := userRolesFromPB[r]
+		if !ok {
+			v.Add(fmt.Sprintf("required_reviewer_roles.%d", i), "%s", ErrInvalidRole)
+		}
+
+		res.RequiredRoles = append(res.RequiredRoles, role)
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return res, nil
+}
+
+// validateRequest runs checks of request fields with the same rules as the HTTP API.
+func validateRequest(op string, check func(v *validation.Validator)) error {
+	var v validation.Validator
+
+	check(&v)
+
+	if err := v.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Responses -------------------------------------------------
+
+func userToPB(u *domain.User) *pb.User {
+	return &pb.User{
+		UserId:   u.ID,
+		Username: u.Name,
+		TeamName: u.TeamName,
+		IsActive: u.IsActive,
+		Role:     userRolesToPB[u.Role],
+	}
+}
+
+func usersToPB(users []*domain.User) []*pb.User {
+	res := make([]*pb.User, 0, len(users))
+	for _, u := range users {
+		res = append(res, userToPB(u))
+	}
+
+	return res
+}
+
+func teamToPB(team *domain.Team) *pb.Team {
+	res := &pb.Team{
+		TeamName: team.Name,
+		Members:  usersToPB(team.Members),
+	}
+
+	for _, r := range team.RequiredRoles {
+		res.RequiredReviewerRoles = append(res.RequiredReviewerRoles, userRolesToPB[r])
+	}
+
+	return res
+}
+
+func teamStatsToPB(stats *domain.TeamStats) *pb.TeamStats {
+	res := &pb.TeamStats{
+		TeamName: stats.Name,
+		Users:    make([]*pb.UserStats, 0, len(stats.Users)),
+		OpenPrs:  make([]*pb.PullRequestStats, 0, len(stats.PRs)),
+	}
+
+	for _, u := range stats.Users {
+		res.Users = append(res.Users, &pb.UserStats{
+			UserId:           u.ID,
+			OpenReviewsCount: int32(u.ReviewsCount),
+		})
+	}
+
+	for _, pr := range stats.PRs {
+		res.OpenPrs = append(res.OpenPrs, &pb.PullRequestStats{
+			PullRequestId:  pr.ID,
+			Status:         prStatusesToPB[pr.Status],
+			ReviewersCount: int32(pr.ReviewersCount),
+		})
+	}
+
+	return res
+}
+
+func pullRequestToPB(pr *domain.PullRequest) *pb.PullRequest {
+	res := &pb.PullRequest{
+		PullRequestId:     pr.ID,
+		PullRequestName:   pr.Name,
+		AuthorId:          pr.AuthorID,
+		Status:            prStatusesToPB[pr.Status],
+		AssignedReviewers: pr.Reviewers,
+		Version:           pr.Version,
+	}
+
+	if pr.CreatedAt != nil {
+		res.CreatedAt = timestamppb.New(*pr.CreatedAt)
+	}
+
+	if pr.MergedAt != nil {
+		res.MergedAt = timestamppb.New(*pr.MergedAt)
+	}
+
+	return res
+}
+
+func pullRequestsShortToPB(prs []*domain.PullRequestShort) []*pb.PullRequestShort {
+	res := make([]*pb.PullRequestShort, 0, len(prs))
+	for _, pr := range prs {
+		res = append(res, &pb.PullRequestShort{
+			PullRequestId:   pr.ID,
+			PullRequestName: pr.Name,
+			AuthorId:        pr.AuthorID,
+			Status:          prStatusesToPB[pr.Status],
+		})
+	}
+
+	return res
+}
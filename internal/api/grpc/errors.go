@@ -0,0 +1,106 @@
+package grpc
+
+import (
+	"avito-task/internal/api/http/response"
+	"avito-task/pkg/logger"
+	"avito-task/pkg/requestid"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	pkgErrors "avito-task/pkg/errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain is the domain of google.rpc.ErrorInfo details, its reason is
+// the same string code as in the HTTP API.
+const errorDomain = "avito-task"
+
+var (
+	ErrInvalidRole = errors.New("unknown user role")
+
+	// httpCodes maps status codes chosen for errors by the HTTP API to gRPC ones,
+	// so both APIs resolve errors with response.ResolveError.
+	httpCodes = map[int]codes.Code{
+		http.StatusBadRequest:          codes.InvalidArgument,
+		http.StatusUnauthorized:        codes.Unauthenticated,
+		http.StatusForbidden:           codes.PermissionDenied,
+		http.StatusNotFound:            codes.NotFound,
+		http.StatusConflict:            codes.FailedPrecondition,
+		http.StatusPreconditionFailed:  codes.Aborted,
+		http.StatusUnprocessableEntity: codes.FailedPrecondition,
+		http.StatusFailedDependency:    codes.Aborted,
+		http.StatusServiceUnavailable:  codes.Unavailable,
+	}
+
+	// strCodes refine the code of errors that share the HTTP status with others.
+	strCodes = map[string]codes.Code{
+		"TEAM_EXISTS": codes.AlreadyExists,
+		"PR_EXISTS":   codes.AlreadyExists,
+	}
+)
+
+// resolveCode returns gRPC code of the error resolved by the HTTP API, Internal by default.
+func resolveCode(errCodes response.ErrCodes) codes.Code {
+	if code, ok := strCodes[errCodes.StrCode]; ok {
+		return code
+	}
+
+	if code, ok := httpCodes[errCodes.HTTPCode]; ok {
+		return code
+	}
+
+	return codes.Internal
+}
+
+// logError logs server errors at error level and client errors at warn level.
+func logError(ctx context.Context, code codes.Code, err error) {
+	level := slog.LevelWarn
+
+	switch code {
+	case codes.Internal, codes.Unavailable, codes.Unknown, codes.DataLoss:
+		level = slog.LevelError
+	}
+
+	slog.Log(ctx, level, "call failed",
+		slog.String("code", code.String()),
+		logger.Err(err),
+	)
+}
+
+func newStatus(ctx context.Context, code codes.Code, strCode string, err error) error {
+	st := status.New(code, err.Error())
+
+	withInfo, detailsErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   strCode,
+		Domain:   errorDomain,
+		Metadata: map[string]string{"request_id": requestid.FromContext(ctx)},
+	})
+	if detailsErr != nil {
+		return st.Err()
+	}
+
+	return withInfo.Err()
+}
+
+// ProcessRequestError converts error of request validation into InvalidArgument status.
+func ProcessRequestError(ctx context.Context, err error) error {
+	logError(ctx, codes.InvalidArgument, err)
+
+	return newStatus(ctx, codes.InvalidArgument, "BAD_REQUEST", pkgErrors.UnwrapAll(err))
+}
+
+// ProcessError converts usecase error into status with the code chosen by the HTTP API:
+// unknown errors are hidden behind Internal.
+func ProcessError(ctx context.Context, err error) error {
+	errCodes, shownErr := response.ResolveError(err)
+	code := resolveCode(errCodes)
+
+	logError(ctx, code, err)
+
+	return newStatus(ctx, code, errCodes.StrCode, shownErr)
+}
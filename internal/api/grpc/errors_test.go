@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"avito-task/internal/repository"
+	"avito-task/internal/usecases"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestProcessError(t *testing.T) {
+	cases := []struct {
+		err     error
+		code    codes.Code
+		strCode string
+	}{
+		{usecases.ErrTeamNameExists, codes.AlreadyExists, "TEAM_EXISTS"},
+		{usecases.ErrPRIDExists, codes.AlreadyExists, "PR_EXISTS"},
+		{usecases.ErrPRMerged, codes.FailedPrecondition, "PR_MERGED"},
+		{usecases.ErrNoCandidate, codes.FailedPrecondition, "NO_CANDIDATE"},
+		{usecases.ErrPRVersionMismatch, codes.Aborted, "PRECONDITION_FAILED"},
+		{usecases.ErrTeamAccessDenied, codes.PermissionDenied, "FORBIDDEN"},
+		{usecases.ErrInvalidAPIKey, codes.Unauthenticated, "UNAUTHORIZED"},
+		{repository.ErrPRNotExists, codes.NotFound, "NOT_FOUND"},
+		{&repository.RetriesExhaustedError{Cause: errors.New("40001")}, codes.Unavailable, "TX_CONFLICT"},
+		{errors.New("unexpected"), codes.Internal, "INTERNAL_ERROR"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.strCode, func(t *testing.T) {
+			st := status.Convert(ProcessError(context.Background(), fmt.Errorf("op: %w", c.err)))
+			require.Equal(t, c.code, st.Code())
+
+			require.Len(t, st.Details(), 1)
+			info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			require.True(t, ok)
+			require.Equal(t, c.strCode, info.GetReason())
+		})
+	}
+}
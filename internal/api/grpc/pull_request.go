@@ -0,0 +1,83 @@
+package grpc
+
+import (
+	"avito-task/internal/api/validation"
+	"avito-task/internal/domain"
+	"avito-task/internal/usecases"
+	"context"
+
+	pb "avito-task/pkg/pb/reviewer/v1"
+)
+
+type PullRequestServer struct {
+	pb.UnimplementedPullRequestServiceServer
+
+	prSvc usecases.PullRequestService
+}
+
+func NewPullRequestServer(prSvc usecases.PullRequestService) *PullRequestServer {
+	return &PullRequestServer{
+		prSvc: prSvc,
+	}
+}
+
+func (s *PullRequestServer) CreatePullRequest(
+	ctx context.Context,
+	req *pb.CreatePullRequestRequest,
+) (*pb.CreatePullRequestResponse, error) {
+	err := validateRequest("PullRequestServer.CreatePullRequest", func(v *validation.Validator) {
+		v.ID("pull_request_id", req.GetPullRequestId())
+		v.Name("pull_request_name", req.GetPullRequestName())
+		v.ID("author_id", req.GetAuthorId())
+	})
+	if err != nil {
+		return nil, ProcessRequestError(ctx, err)
+	}
+
+	res, err := s.prSvc.CreatePullRequest(ctx, &domain.PullRequest{
+		ID:       req.GetPullRequestId(),
+		Name:     req.GetPullRequestName(),
+		AuthorID: req.GetAuthorId(),
+	})
+	if err != nil {
+		return nil, ProcessError(ctx, err)
+	}
+
+	return &pb.CreatePullRequestResponse{Pr: pullRequestToPB(res)}, nil
+}
+
+func (s *PullRequestServer) Merge(ctx context.Context, req *pb.MergeRequest) (*pb.MergeResponse, error) {
+	err := validateRequest("PullRequestServer.Merge", func(v *validation.Validator) {
+		v.ID("pull_request_id", req.GetPullRequestId())
+	})
+	if err != nil {
+		return nil, ProcessRequestError(ctx, err)
+	}
+
+	res, err := s.prSvc.Merge(ctx, req.GetPullRequestId(), req.GetIfVersion())
+	if err != nil {
+		return nil, ProcessError(ctx, err)
+	}
+
+	return &pb.MergeResponse{Pr: pullRequestToPB(res)}, nil
+}
+
+func (s *PullRequestServer) Reassign(ctx context.Context, req *pb.ReassignRequest) (*pb.ReassignResponse, error) {
+	err := validateRequest("PullRequestServer.Reassign", func(v *validation.Validator) {
+		v.ID("pull_request_id", req.GetPullRequestId())
+		v.ID("old_reviewer_id", req.GetOldReviewerId())
+	})
+	if err != nil {
+		return nil, ProcessRequestError(ctx, err)
+	}
+
+	newRewID, res, err := s.prSvc.Reassign(ctx, req.GetPullRequestId(), req.GetOldReviewerId(), req.GetIfVersion())
+	if err != nil {
+		return nil, ProcessError(ctx, err)
+	}
+
+	return &pb.ReassignResponse{
+		Pr:         pullRequestToPB(res),
+		ReplacedBy: newRewID,
+	}, nil
+}
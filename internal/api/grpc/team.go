@@ -0,0 +1,89 @@
+package grpc
+
+import (
+	"avito-task/internal/api/validation"
+	"avito-task/internal/usecases"
+	"context"
+
+	pb "avito-task/pkg/pb/reviewer/v1"
+)
+
+type TeamServer struct {
+	pb.UnimplementedTeamServiceServer
+
+	teamSvc usecases.TeamService
+}
+
+func NewTeamServer(teamSvc usecases.TeamService) *TeamServer {
+	return &TeamServer{
+		teamSvc: teamSvc,
+	}
+}
+
+func (s *TeamServer) CreateTeam(ctx context.Context, req *pb.CreateTeamRequest) (*pb.CreateTeamResponse, error) {
+	team, err := teamFromPB(req.GetTeam())
+	if err != nil {
+		return nil, ProcessRequestError(ctx, err)
+	}
+
+	res, err := s.teamSvc.CreateTeam(ctx, team)
+	if err != nil {
+		return nil, ProcessError(ctx, err)
+	}
+
+	return &pb.CreateTeamResponse{Team: teamToPB(res)}, nil
+}
+
+func (s *TeamServer) GetTeam(ctx context.Context, req *pb.GetTeamRequest) (*pb.GetTeamResponse, error) {
+	err := validateRequest("TeamServer.GetTeam", func(v *validation.Validator) {
+		v.ID("team_name", req.GetTeamName())
+	})
+	if err != nil {
+		return nil, ProcessRequestError(ctx, err)
+	}
+
+	res, err := s.teamSvc.GetTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, ProcessError(ctx, err)
+	}
+
+	return &pb.GetTeamResponse{Team: teamToPB(res)}, nil
+}
+
+func (s *TeamServer) GetTeamStats(ctx context.Context, req *pb.GetTeamStatsRequest) (*pb.GetTeamStatsResponse, error) {
+	err := validateRequest("TeamServer.GetTeamStats", func(v *validation.Validator) {
+		v.ID("team_name", req.GetTeamName())
+	})
+	if err != nil {
+		return nil, ProcessRequestError(ctx, err)
+	}
+
+	res, err := s.teamSvc.GetTeamStats(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, ProcessError(ctx, err)
+	}
+
+	return &pb.GetTeamStatsResponse{Stats: teamStatsToPB(res)}, nil
+}
+
+func (s *TeamServer) DeactivateTeam(
+	ctx context.Context,
+	req *pb.DeactivateTeamRequest,
+) (*pb.DeactivateTeamResponse, error) {
+	err := validateRequest("TeamServer.DeactivateTeam", func(v *validation.Validator) {
+		v.ID("team_name", req.GetTeamName())
+	})
+	if err != nil {
+		return nil, ProcessRequestError(ctx, err)
+	}
+
+	res, err := s.teamSvc.DeactivateTeam(ctx, req.GetTeamName())
+	if err != nil {
+		return nil, ProcessError(ctx, err)
+	}
+
+	return &pb.DeactivateTeamResponse{
+		TeamName: req.GetTeamName(),
+		Users:    usersToPB(res),
+	}, nil
+}
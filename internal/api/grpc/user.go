@@ -0,0 +1,56 @@
+package grpc
+
+import (
+	"avito-task/internal/api/validation"
+	"avito-task/internal/usecases"
+	"context"
+
+	pb "avito-task/pkg/pb/reviewer/v1"
+)
+
+type UserServer struct {
+	pb.UnimplementedUserServiceServer
+
+	userSvc usecases.UserService
+}
+
+func NewUserServer(userSvc usecases.UserService) *UserServer {
+	return &UserServer{
+		userSvc: userSvc,
+	}
+}
+
+func (s *UserServer) SetIsActive(ctx context.Context, req *pb.SetIsActiveRequest) (*pb.SetIsActiveResponse, error) {
+	err := validateRequest("UserServer.SetIsActive", func(v *validation.Validator) {
+		v.ID("user_id", req.GetUserId())
+	})
+	if err != nil {
+		return nil, ProcessRequestError(ctx, err)
+	}
+
+	res, err := s.userSvc.SetIsActive(ctx, req.GetUserId(), req.GetIsActive())
+	if err != nil {
+		return nil, ProcessError(ctx, err)
+	}
+
+	return &pb.SetIsActiveResponse{User: userToPB(res)}, nil
+}
+
+func (s *UserServer) GetReview(ctx context.Context, req *pb.GetReviewRequest) (*pb.GetReviewResponse, error) {
+	err := validateRequest("UserServer.GetReview", func(v *validation.Validator) {
+		v.ID("user_id", req.GetUserId())
+	})
+	if err != nil {
+		return nil, ProcessRequestError(ctx, err)
+	}
+
+	res, err := s.userSvc.GetReview(ctx, req.GetUserId())
+	if err != nil {
+		return nil, ProcessError(ctx, err)
+	}
+
+	return &pb.GetReviewResponse{
+		UserId:       req.GetUserId(),
+		PullRequests: pullRequestsShortToPB(res),
+	}, nil
+}
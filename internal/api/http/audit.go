@@ -0,0 +1,50 @@
+package http
+
+import (
+	"avito-task/internal/api/http/response"
+	"avito-task/internal/api/http/types"
+	"avito-task/internal/config"
+	"avito-task/internal/domain"
+	"avito-task/internal/usecases"
+	"avito-task/pkg/http/handlers"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type AuditHandler struct {
+	auditSvc usecases.AuditService
+	pathCfg config.PathConfig
+}
+
+func NewAuditHandler(
+	auditSvc usecases.AuditService,
+	pathCfg config.PathConfig,
+) *AuditHandler {
+	return &AuditHandler{
+		auditSvc: auditSvc,
+		pathCfg: pathCfg,
+	}
+}
+
+func (h *AuditHandler) WithAuditHandlers() handlers.RouterOption {
+	return func (r chi.Router) {
+		r.With(requireRoles(domain.AccessAdmin)).Get(h.pathCfg.GetAudit, h.getHandler)
+	}
+}
+
+func (h *AuditHandler) getHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateGetAuditRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	res, err := h.auditSvc.List(r.Context(), req.Filter)
+	if err != nil {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	response.WriteResponse(w, http.StatusOK, types.CreateGetAuditResponse(res, req.Filter.Limit))
+}
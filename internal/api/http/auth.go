@@ -0,0 +1,182 @@
+package http
+
+import (
+	"avito-task/internal/api/http/response"
+	"avito-task/internal/api/http/types"
+	"avito-task/internal/config"
+	"avito-task/internal/domain"
+	"avito-task/internal/usecases"
+	"avito-task/pkg/http/handlers"
+	"avito-task/pkg/jwks"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	pkgMiddleware "avito-task/pkg/http/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	anonymousPrincipalID = "anonymous"
+	bearerPrefix         = "Bearer "
+)
+
+var (
+	anyRole = []domain.AccessRole{
+		domain.AccessAdmin, domain.AccessTeamLead, domain.AccessMember, domain.AccessIntegration,
+	}
+	managerRoles = []domain.AccessRole{domain.AccessAdmin, domain.AccessTeamLead}
+)
+
+// requireRoles allows only principals with given roles and passes principal
+// to the usecase layer as operation actor.
+func requireRoles(roles ...domain.AccessRole) func(http.Handler) http.Handler {
+	strRoles := make([]string, 0, len(roles))
+	for _, r := range roles {
+		strRoles = append(strRoles, string(r))
+	}
+
+	requireMw := pkgMiddleware.RequireRoles(response.ProcessError, strRoles...)
+
+	return func(next http.Handler) http.Handler {
+		return requireMw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, _ := pkgMiddleware.PrincipalFromContext(r.Context())
+
+			ctx := usecases.WithActor(r.Context(), &domain.Actor{
+				ID:       p.ID,
+				Role:     domain.AccessRole(p.Role),
+				TeamName: p.TeamName,
+			})
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}))
+	}
+}
+
+type AuthHandler struct {
+	authSvc  usecases.AuthService
+	authCfg  config.AuthConfig
+	pathCfg  config.PathConfig
+	verifier *jwks.Verifier
+}
+
+// NewAuthHandler creates handler; verifier may be nil if bearer tokens are disabled.
+func NewAuthHandler(
+	authSvc usecases.AuthService,
+	authCfg config.AuthConfig,
+	pathCfg config.PathConfig,
+	verifier *jwks.Verifier,
+) *AuthHandler {
+	return &AuthHandler{
+		authSvc:  authSvc,
+		authCfg:  authCfg,
+		pathCfg:  pathCfg,
+		verifier: verifier,
+	}
+}
+
+// Authenticate implements middleware.Authenticator. With disabled authentication
+// every request is treated as made by admin.
+func (h *AuthHandler) Authenticate(r *http.Request) (*pkgMiddleware.Principal, error) {
+	return h.AuthenticateCredentials(r.Context(), r.Header.Get("Authorization"), r.Header.Get(h.authCfg.APIKeyHeader))
+}
+
+// AuthenticateCredentials checks Authorization value and API key taken from any transport
+// (HTTP headers or gRPC metadata).
+func (h *AuthHandler) AuthenticateCredentials(
+	ctx context.Context,
+	authorization, rawKey string,
+) (*pkgMiddleware.Principal, error) {
+	if !h.authCfg.Enabled {
+		return &pkgMiddleware.Principal{ID: anonymousPrincipalID, Role: string(domain.AccessAdmin)}, nil
+	}
+
+	if token, ok := strings.CutPrefix(authorization, bearerPrefix); ok && h.verifier != nil {
+		return h.authenticateToken(token)
+	}
+
+	if len(rawKey) == 0 {
+		return nil, nil
+	}
+
+	key, err := h.authSvc.Authenticate(ctx, rawKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pkgMiddleware.Principal{
+		ID:       key.ID,
+		Role:     string(key.Role),
+		TeamName: key.TeamName,
+	}, nil
+}
+
+// authenticateToken maps claims of verified token to principal. Role defaults to member,
+// team claim is required for team-scoped roles.
+func (h *AuthHandler) authenticateToken(token string) (*pkgMiddleware.Principal, error) {
+	const op = "AuthHandler.authenticateToken"
+
+	claims, err := h.verifier.Verify(token)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	userID, _ := claims[h.authCfg.JWT.UserIDClaim].(string)
+	teamName, _ := claims[h.authCfg.JWT.TeamClaim].(string)
+	role := domain.AccessMember
+
+	if claimRole, ok := claims[h.authCfg.JWT.RoleClaim].(string); ok {
+		role = domain.AccessRole(claimRole)
+	}
+
+	if len(userID) == 0 || !role.IsValid() || (role.IsTeamScoped() && len(teamName) == 0) {
+		return nil, fmt.Errorf("%s: %w: unexpected claims", op, jwks.ErrInvalidToken)
+	}
+
+	return &pkgMiddleware.Principal{
+		ID:       userID,
+		Role:     string(role),
+		TeamName: teamName,
+	}, nil
+}
+
+func (h *AuthHandler) WithAuthHandlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.With(requireRoles(domain.AccessAdmin)).Post(h.pathCfg.IssueAPIKey, h.issueHandler)
+		r.With(requireRoles(domain.AccessAdmin)).Post(h.pathCfg.RevokeAPIKey, h.revokeHandler)
+	}
+}
+
+func (h *AuthHandler) issueHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateIssueAPIKeyRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	rawKey, res, err := h.authSvc.IssueKey(r.Context(), req.Key)
+	if err != nil {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	response.WriteResponse(w, http.StatusCreated, types.CreateIssueAPIKeyResponse(rawKey, res))
+}
+
+func (h *AuthHandler) revokeHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateRevokeAPIKeyRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	res, err := h.authSvc.RevokeKey(r.Context(), req.ID)
+	if err != nil {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	response.WriteResponse(w, http.StatusOK, types.CreateRevokeAPIKeyResponse(res))
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"avito-task/internal/api/http/response"
+	"avito-task/internal/api/http/types"
+	"avito-task/internal/config"
+	"avito-task/internal/domain"
+	"avito-task/internal/usecases"
+	"avito-task/pkg/http/handlers"
+	"avito-task/pkg/logger"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	pkgMiddleware "avito-task/pkg/http/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// batchOpRoles mirrors roles required by the endpoints of the operations.
+var batchOpRoles = map[usecases.BatchOpType][]domain.AccessRole{
+	usecases.BatchCreatePR:    anyRole,
+	usecases.BatchMergePR:     anyRole,
+	usecases.BatchReassign:    {domain.AccessAdmin, domain.AccessTeamLead, domain.AccessMember},
+	usecases.BatchSetIsActive: managerRoles,
+}
+
+type BatchHandler struct {
+	batchSvc      usecases.BatchService
+	pathCfg       config.PathConfig
+	maxOperations int
+}
+
+func NewBatchHandler(
+	batchSvc usecases.BatchService,
+	pathCfg config.PathConfig,
+	maxOperations int,
+) *BatchHandler {
+	return &BatchHandler{
+		batchSvc:      batchSvc,
+		pathCfg:       pathCfg,
+		maxOperations: maxOperations,
+	}
+}
+
+func (h *BatchHandler) WithBatchHandlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.With(requireRoles(anyRole...)).Post(h.pathCfg.Batch, h.batchHandler)
+	}
+}
+
+func (h *BatchHandler) batchHandler(w http.ResponseWriter, r *http.Request) {
+	const op = "BatchHandler.batchHandler"
+
+	req, err := types.CreateBatchRequest(r, h.maxOperations)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	actor, _ := usecases.ActorFromContext(r.Context())
+
+	items := make([]*types.BatchItemResult, len(req.Operations))
+	allowed := make([]*usecases.BatchOperation, 0, len(req.Operations))
+	allowedIdx := make([]int, 0, len(req.Operations))
+
+	for i, batchOp := range req.Operations {
+		if slices.Contains(batchOpRoles[batchOp.Type], actor.Role) {
+			allowed = append(allowed, batchOp)
+			allowedIdx = append(allowedIdx, i)
+			continue
+		}
+
+		// Atomic batch can not be applied partially, so it is rejected as a whole.
+		if req.Atomic {
+			response.ProcessError(w, r, fmt.Errorf("%s: operation %d: %w", op, i, pkgMiddleware.ErrForbidden))
+			return
+		}
+
+		items[i] = batchItemError(r, i, batchOp, pkgMiddleware.ErrForbidden)
+	}
+
+	results, err := h.batchSvc.Execute(r.Context(), allowed, req.Atomic)
+	if err != nil {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	for j, res := range results {
+		i := allowedIdx[j]
+
+		if res.Err != nil {
+			items[i] = batchItemError(r, i, allowed[j], res.Err)
+		} else {
+			items[i] = types.CreateBatchItemResult(i, allowed[j], res)
+		}
+	}
+
+	response.WriteResponse(w, http.StatusOK, types.CreateBatchResponse(req.Atomic, items))
+}
+
+// batchItemError resolves error of the operation the same way as error of the whole request.
+func batchItemError(r *http.Request, index int, batchOp *usecases.BatchOperation, err error) *types.BatchItemResult {
+	fullErr := err
+	codes, err := response.ResolveError(err)
+
+	if codes.HTTPCode >= http.StatusInternalServerError {
+		slog.ErrorContext(r.Context(), "batch operation failed",
+			slog.Int("index", index),
+			logger.Err(fullErr),
+		)
+	}
+
+	return &types.BatchItemResult{
+		Index:  index,
+		Op:     batchOp.Type,
+		Status: codes.HTTPCode,
+		Error: &types.BatchItemError{
+			StrCode: codes.StrCode,
+			Message: err.Error(),
+		},
+	}
+}
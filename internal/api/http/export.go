@@ -0,0 +1,151 @@
+package http
+
+import (
+	"avito-task/internal/api/http/response"
+	"avito-task/internal/api/http/types"
+	"avito-task/internal/config"
+	"avito-task/internal/domain"
+	"avito-task/internal/usecases"
+	"avito-task/pkg/http/handlers"
+	"avito-task/pkg/logger"
+	"bufio"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	exportBufferSize = 32 * 1024
+	// exportFlushEvery is the number of rows after which the stream is flushed to the client.
+	exportFlushEvery = 1000
+)
+
+var exportContentTypes = map[types.ImportFormat]string{
+	types.ImportFormatCSV:    "text/csv; charset=utf-8",
+	types.ImportFormatNDJSON: "application/x-ndjson",
+}
+
+type ExportHandler struct {
+	exportSvc usecases.ExportService
+	pathCfg   config.PathConfig
+	timeout   time.Duration
+}
+
+func NewExportHandler(
+	exportSvc usecases.ExportService,
+	pathCfg config.PathConfig,
+	timeout time.Duration,
+) *ExportHandler {
+	return &ExportHandler{
+		exportSvc: exportSvc,
+		pathCfg:   pathCfg,
+		timeout:   timeout,
+	}
+}
+
+func (h *ExportHandler) WithExportHandlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.With(requireRoles(domain.AccessAdmin, domain.AccessIntegration)).Get(h.pathCfg.Export, h.exportHandler)
+	}
+}
+
+// sentWriter sends response headers on the first write, until then the handler
+// may still respond with an error.
+type sentWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	sent        bool
+}
+
+func (sw *sentWriter) Write(b []byte) (int, error) {
+	if !sw.sent {
+		sw.sent = true
+		sw.w.Header().Set("Content-Type", sw.contentType)
+		sw.w.WriteHeader(http.StatusOK)
+	}
+
+	return sw.w.Write(b)
+}
+
+// exportHandler streams rows as the service reads them. An error after the stream has
+// started can not be reported by status, so the connection is aborted instead and the
+// client sees a truncated response.
+func (h *ExportHandler) exportHandler(w http.ResponseWriter, r *http.Request) {
+	const op = "ExportHandler.exportHandler"
+
+	req, err := types.CreateExportRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+
+	// Export may take longer than ordinary requests the server write timeout is set for.
+	if err = rc.SetWriteDeadline(time.Now().Add(h.timeout)); err != nil {
+		slog.WarnContext(r.Context(), "failed to extend export write deadline", logger.Err(err))
+	}
+
+	sw := &sentWriter{w: w, contentType: exportContentTypes[req.Format]}
+	buf := bufio.NewWriterSize(sw, exportBufferSize)
+
+	ew, err := types.NewExportWriter(buf, req)
+	if err != nil {
+		response.ProcessError(w, r, fmt.Errorf("%s: %w", op, err))
+		return
+	}
+
+	rows := 0
+
+	err = h.exportSvc.Export(r.Context(), req.Entities, req.Filter, func(rec *domain.ExportRecord) error {
+		if err := ew.Write(rec); err != nil {
+			return err
+		}
+
+		if rows++; rows%exportFlushEvery != 0 {
+			return nil
+		}
+
+		if err := ew.Flush(); err != nil {
+			return err
+		}
+
+		if err := buf.Flush(); err != nil {
+			return err
+		}
+
+		return rc.Flush()
+	})
+
+	if err == nil {
+		if err = ew.Flush(); err == nil {
+			err = buf.Flush()
+		}
+	}
+
+	if err == nil {
+		if !sw.sent {
+			// Nothing has been exported (NDJSON without rows).
+			w.Header().Set("Content-Type", sw.contentType)
+			w.WriteHeader(http.StatusOK)
+		}
+
+		return
+	}
+
+	if !sw.sent {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	slog.ErrorContext(r.Context(), "export aborted",
+		slog.String("op", op),
+		slog.Int("rows", rows),
+		logger.Err(err),
+	)
+
+	panic(http.ErrAbortHandler)
+}
@@ -0,0 +1,47 @@
+package http
+
+import (
+	"avito-task/internal/api/graphql"
+	"avito-task/internal/api/http/response"
+	"avito-task/internal/api/http/types"
+	"avito-task/internal/config"
+	"avito-task/pkg/http/handlers"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type GraphQLHandler struct {
+	schema  *graphql.Schema
+	pathCfg config.PathConfig
+}
+
+func NewGraphQLHandler(
+	schema *graphql.Schema,
+	pathCfg config.PathConfig,
+) *GraphQLHandler {
+	return &GraphQLHandler{
+		schema:  schema,
+		pathCfg: pathCfg,
+	}
+}
+
+// WithGraphQLHandlers opens the endpoint to any role, mutations check roles themselves
+// the same way as the REST routes.
+func (h *GraphQLHandler) WithGraphQLHandlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.With(requireRoles(anyRole...)).Post(h.pathCfg.GraphQL, h.queryHandler)
+	}
+}
+
+func (h *GraphQLHandler) queryHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateGraphQLRequest(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	res := h.schema.Exec(r.Context(), req.Query, req.OperationName, req.Variables)
+
+	response.WriteResponse(w, http.StatusOK, res)
+}
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/usecases"
+	"context"
+
+	pkgMiddleware "avito-task/pkg/http/middleware"
+)
+
+// IdempotencyHandler implements middleware.IdempotencyStore on top of the usecase.
+type IdempotencyHandler struct {
+	idemSvc usecases.IdempotencyService
+}
+
+func NewIdempotencyHandler(idemSvc usecases.IdempotencyService) *IdempotencyHandler {
+	return &IdempotencyHandler{
+		idemSvc: idemSvc,
+	}
+}
+
+func (h *IdempotencyHandler) Begin(ctx context.Context, scope, key, fingerprint string) (*pkgMiddleware.CachedResponse, error) {
+	resp, err := h.idemSvc.Begin(ctx, domain.IdempotencyKey{Scope: scope, Key: key, Fingerprint: fingerprint})
+	if err != nil || resp == nil {
+		return nil, err
+	}
+
+	return &pkgMiddleware.CachedResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Headers,
+		Body:       resp.Body,
+	}, nil
+}
+
+func (h *IdempotencyHandler) Complete(
+	ctx context.Context,
+	scope, key, fingerprint string,
+	resp *pkgMiddleware.CachedResponse,
+) error {
+	return h.idemSvc.Complete(ctx, domain.IdempotencyKey{Scope: scope, Key: key, Fingerprint: fingerprint}, &domain.IdempotentResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Headers,
+		Body:       resp.Body,
+	})
+}
+
+func (h *IdempotencyHandler) Abort(ctx context.Context, scope, key, fingerprint string) error {
+	return h.idemSvc.Abort(ctx, domain.IdempotencyKey{Scope: scope, Key: key, Fingerprint: fingerprint})
+}
@@ -0,0 +1,53 @@
+package http
+
+import (
+	"avito-task/internal/api/http/response"
+	"avito-task/internal/api/http/types"
+	"avito-task/internal/config"
+	"avito-task/internal/domain"
+	"avito-task/internal/usecases"
+	"avito-task/pkg/http/handlers"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ImportHandler struct {
+	importSvc  usecases.ImportService
+	pathCfg    config.PathConfig
+	maxRecords int
+}
+
+func NewImportHandler(
+	importSvc usecases.ImportService,
+	pathCfg config.PathConfig,
+	maxRecords int,
+) *ImportHandler {
+	return &ImportHandler{
+		importSvc:  importSvc,
+		pathCfg:    pathCfg,
+		maxRecords: maxRecords,
+	}
+}
+
+func (h *ImportHandler) WithImportHandlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.With(requireRoles(domain.AccessAdmin)).Post(h.pathCfg.ImportPR, h.importPRHandler)
+	}
+}
+
+func (h *ImportHandler) importPRHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateImportPRRequest(r, h.maxRecords)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	report, err := h.importSvc.ImportPullRequests(r.Context(), req.Records, req.DryRun)
+	if err != nil {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	response.WriteResponse(w, http.StatusOK, types.CreateImportReport(report))
+}
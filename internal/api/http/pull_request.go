@@ -4,6 +4,7 @@ import (
 	"avito-task/internal/api/http/response"
 	"avito-task/internal/api/http/types"
 	"avito-task/internal/config"
+	"avito-task/internal/domain"
 	"avito-task/internal/usecases"
 	"avito-task/pkg/http/handlers"
 	"net/http"
@@ -28,56 +29,59 @@ func NewPullRequestHandler(
 
 func (h *PullRequestHandler) WithPRHandlers() handlers.RouterOption {
 	return func (r chi.Router) {
-		r.Post(h.pathCfg.CreatePR, h.createHandler)
-		r.Post(h.pathCfg.MergePR, h.mergeHandler)
-		r.Post(h.pathCfg.ReassignPR, h.reassignHandler)
+		r.With(requireRoles(anyRole...)).Post(h.pathCfg.CreatePR, h.createHandler)
+		r.With(requireRoles(anyRole...)).Post(h.pathCfg.MergePR, h.mergeHandler)
+		r.With(requireRoles(domain.AccessAdmin, domain.AccessTeamLead, domain.AccessMember)).Post(h.pathCfg.ReassignPR, h.reassignHandler)
 	}
 }
 
 func (h *PullRequestHandler) createHandler(w http.ResponseWriter, r *http.Request) {
 	req, err := types.MakeCreatePRRequest(r)
 	if err != nil {
-		response.ProcessCreatingRequestError(w, err)
+		response.ProcessCreatingRequestError(w, r, err)
 		return
 	}
 
 	res, err := h.prSvc.CreatePullRequest(r.Context(), req.PR)
 	if err != nil {
-		response.ProcessError(w, err)
+		response.ProcessError(w, r, err)
 		return
 	}
 
+	w.Header().Set("ETag", types.PRETag(res))
 	response.WriteResponse(w, http.StatusCreated, types.MakeCreatePRResponse(res))
 }
 
 func (h *PullRequestHandler) mergeHandler(w http.ResponseWriter, r *http.Request) {
 	req, err := types.CreateMergePRRequest(r)
 	if err != nil {
-		response.ProcessCreatingRequestError(w, err)
+		response.ProcessCreatingRequestError(w, r, err)
 		return
 	}
 
-	res, err := h.prSvc.Merge(r.Context(), req.PRID)
+	res, err := h.prSvc.Merge(r.Context(), req.PRID, req.IfVersion)
 	if err != nil {
-		response.ProcessError(w, err)
+		response.ProcessError(w, r, err)
 		return
 	}
 
+	w.Header().Set("ETag", types.PRETag(res))
 	response.WriteResponse(w, http.StatusOK, types.CreateMergePRResponse(res))
 }
 
 func (h *PullRequestHandler) reassignHandler(w http.ResponseWriter, r *http.Request) {
 	req, err := types.CreateReassignRequest(r)
 	if err != nil {
-		response.ProcessCreatingRequestError(w, err)
+		response.ProcessCreatingRequestError(w, r, err)
 		return
 	}
 
-	newRewID, pr, err := h.prSvc.Reassign(r.Context(), req.PRID, req.OldRewID)
+	newRewID, pr, err := h.prSvc.Reassign(r.Context(), req.PRID, req.OldRewID, req.IfVersion)
 	if err != nil {
-		response.ProcessError(w, err)
+		response.ProcessError(w, r, err)
 		return
 	}
 
+	w.Header().Set("ETag", types.PRETag(pr))
 	response.WriteResponse(w, http.StatusOK, types.CreateReassignResponse(newRewID, pr))
 }
@@ -3,11 +3,15 @@ package response
 import (
 	"avito-task/internal/repository"
 	"avito-task/internal/usecases"
+	"avito-task/pkg/logger"
+	"avito-task/pkg/requestid"
 	"errors"
-	"log"
+	"log/slog"
 	"net/http"
 
 	pkgErrors "avito-task/pkg/errors"
+	pkgMiddleware "avito-task/pkg/http/middleware"
+	"avito-task/pkg/jwks"
 )
 
 type ErrCodes struct {
@@ -16,8 +20,11 @@ type ErrCodes struct {
 }
 
 type ErrorDetails struct {
-	StrCode string `json:"code"`
-	Message string `json:"message"`
+	StrCode   string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+	// Fields lists every invalid part of request when it does not match the API specification.
+	Fields []pkgMiddleware.FieldError `json:"fields,omitempty"`
 }
 
 type ErrorResponse struct {
@@ -35,31 +42,68 @@ var (
 		repository.ErrTeamNotExists:  {http.StatusNotFound, "NOT_FOUND"},
 		repository.ErrUserNotExists:  {http.StatusNotFound, "NOT_FOUND"},
 		repository.ErrPRNotExists: {http.StatusNotFound, "NOT_FOUND"},
+		repository.ErrAPIKeyNotExists: {http.StatusNotFound, "NOT_FOUND"},
+		repository.ErrTxRetriesExhausted: {http.StatusServiceUnavailable, "TX_CONFLICT"},
 
 		usecases.ErrTeamNameExists: {http.StatusBadRequest, "TEAM_EXISTS"},
 		usecases.ErrPRIDExists:  {http.StatusConflict, "PR_EXISTS"},
 		usecases.ErrPRMerged:    {http.StatusConflict, "PR_MERGED"},
 		usecases.ErrNotAssigned: {http.StatusConflict, "NOT_ASSIGNED"},
 		usecases.ErrNoCandidate: {http.StatusConflict, "NO_CANDIDATE"},
+		usecases.ErrPRVersionMismatch: {http.StatusPreconditionFailed, "PRECONDITION_FAILED"},
+		usecases.ErrBatchAborted:      {http.StatusFailedDependency, "BATCH_ABORTED"},
+
+		usecases.ErrIdempotencyKeyReused:       {http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED"},
+		usecases.ErrIdempotencyInProgress:      {http.StatusConflict, "IDEMPOTENCY_IN_PROGRESS"},
+		pkgMiddleware.ErrInvalidIdempotencyKey: {http.StatusBadRequest, "BAD_REQUEST"},
+
+		usecases.ErrInvalidAPIKey:      {http.StatusUnauthorized, "UNAUTHORIZED"},
+		usecases.ErrTeamAccessDenied:   {http.StatusForbidden, "FORBIDDEN"},
+		jwks.ErrInvalidToken:           {http.StatusUnauthorized, "UNAUTHORIZED"},
+		pkgMiddleware.ErrUnauthorized:  {http.StatusUnauthorized, "UNAUTHORIZED"},
+		pkgMiddleware.ErrForbidden:     {http.StatusForbidden, "FORBIDDEN"},
 	}
 )
 
-func ProcessCreatingRequestError(w http.ResponseWriter, err error) {
-	log.Print("[ERROR] ", err.Error())
+// logError logs server errors at error level and client errors at warn level.
+func logError(r *http.Request, httpCode int, err error) {
+	level := slog.LevelWarn
+	if httpCode >= http.StatusInternalServerError {
+		level = slog.LevelError
+	}
+
+	slog.Log(r.Context(), level, "request failed",
+		slog.Int("status", httpCode),
+		logger.Err(err),
+	)
+}
+
+func ProcessCreatingRequestError(w http.ResponseWriter, r *http.Request, err error) {
+	logError(r, http.StatusBadRequest, err)
+
+	strCode := "BAD_REQUEST"
+
+	var fields []pkgMiddleware.FieldError
+
+	var validationErr *pkgMiddleware.ValidationError
+	if errors.As(err, &validationErr) {
+		strCode = "VALIDATION_ERROR"
+		fields = validationErr.Fields
+	}
 
 	err = pkgErrors.UnwrapAll(err)
 
-	WriteResponse(w, http.StatusBadRequest, ErrorResponse{
-		Details: ErrorDetails{
-			StrCode: "BAD_REQUEST",
-			Message: err.Error(),
-		},
-	})
+	writeError(w, r, http.StatusBadRequest, ErrorDetails{
+		StrCode:   strCode,
+		Message:   err.Error(),
+		RequestID: requestid.FromContext(r.Context()),
+		Fields:    fields,
+	}, err)
 }
 
-func ProcessError(w http.ResponseWriter, err error) {
-	log.Print("[ERROR] ", err.Error())
-
+// ResolveError returns codes of the error and the error to show to client: unknown
+// errors are hidden behind ErrInternal and all not found ones behind ErrNotFound.
+func ResolveError(err error) (ErrCodes, error) {
 	err = pkgErrors.UnwrapAll(err)
 	codes := errCodes[ErrInternal]
 
@@ -74,10 +118,18 @@ func ProcessError(w http.ResponseWriter, err error) {
 		codes = errCodes[ErrNotFound]
 	}
 
-	WriteResponse(w, codes.HTTPCode, ErrorResponse{
-		Details: ErrorDetails{
-			StrCode: codes.StrCode,
-			Message: err.Error(),
-		},
-	})
+	return codes, err
+}
+
+func ProcessError(w http.ResponseWriter, r *http.Request, err error) {
+	fullErr := err
+	codes, err := ResolveError(err)
+
+	logError(r, codes.HTTPCode, fullErr)
+
+	writeError(w, r, codes.HTTPCode, ErrorDetails{
+		StrCode:   codes.StrCode,
+		Message:   err.Error(),
+		RequestID: requestid.FromContext(r.Context()),
+	}, pkgErrors.UnwrapAll(fullErr))
 }
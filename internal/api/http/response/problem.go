@@ -0,0 +1,142 @@
+package response
+
+import (
+	"avito-task/internal/repository"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	pkgMiddleware "avito-task/pkg/http/middleware"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+
+	// problemTypePrefix is prepended to the kebab-cased error code to build problem type URI.
+	problemTypePrefix = "urn:avito-task:problem:"
+)
+
+// Problem is RFC 7807 problem details object with error code, request ID,
+// not found entity and invalid fields as extension members.
+type Problem struct {
+	Type      string                     `json:"type"`
+	Title     string                     `json:"title"`
+	Status    int                        `json:"status"`
+	Detail    string                     `json:"detail,omitempty"`
+	Instance  string                     `json:"instance,omitempty"`
+	Code      string                     `json:"code"`
+	RequestID string                     `json:"request_id,omitempty"`
+	Entity    string                     `json:"entity,omitempty"`
+	Fields    []pkgMiddleware.FieldError `json:"fields,omitempty"`
+}
+
+var (
+	problemTitles = map[string]string{
+		"INTERNAL_ERROR":          "Internal server error",
+		"NOT_FOUND":               "Resource not found",
+		"TX_CONFLICT":             "Transaction conflict",
+		"TEAM_EXISTS":             "Team already exists",
+		"PR_EXISTS":               "Pull request already exists",
+		"PR_MERGED":               "Pull request is merged",
+		"NOT_ASSIGNED":            "Reviewer is not assigned",
+		"NO_CANDIDATE":            "No replacement candidate",
+		"PRECONDITION_FAILED":     "Pull request version mismatch",
+		"BATCH_ABORTED":           "Batch operation not applied",
+		"IDEMPOTENCY_KEY_REUSED":  "Idempotency key reused",
+		"IDEMPOTENCY_IN_PROGRESS": "Idempotent request in progress",
+		"BAD_REQUEST":             "Malformed request",
+		"VALIDATION_ERROR":        "Request validation failed",
+		"UNAUTHORIZED":            "Authentication required",
+		"FORBIDDEN":               "Access denied",
+	}
+
+	// notFoundEntities names the entity that was not found, ProcessError hides it in the legacy response.
+	notFoundEntities = map[error]string{
+		repository.ErrTeamNotExists:   "team",
+		repository.ErrUserNotExists:   "user",
+		repository.ErrPRNotExists:     "pull_request",
+		repository.ErrAPIKeyNotExists: "api_key",
+	}
+)
+
+// problemType returns type URI of the error code, e.g. urn:avito-task:problem:not-found.
+func problemType(strCode string) string {
+	return problemTypePrefix + strings.ReplaceAll(strings.ToLower(strCode), "_", "-")
+}
+
+// NewProblem builds problem details of the error response for the request.
+func NewProblem(r *http.Request, httpCode int, details ErrorDetails) *Problem {
+	title, ok := problemTitles[details.StrCode]
+	if !ok {
+		title = http.StatusText(httpCode)
+	}
+
+	return &Problem{
+		Type:      problemType(details.StrCode),
+		Title:     title,
+		Status:    httpCode,
+		Detail:    details.Message,
+		Instance:  r.URL.Path,
+		Code:      details.StrCode,
+		RequestID: details.RequestID,
+		Fields:    details.Fields,
+	}
+}
+
+// AcceptsProblem reports whether the client prefers application/problem+json
+// to application/json according to the Accept header.
+func AcceptsProblem(r *http.Request) bool {
+	var problemQ, jsonQ float64
+
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, params, _ := strings.Cut(mediaRange, ";")
+
+			q := 1.0
+
+			for _, param := range strings.Split(params, ";") {
+				name, val, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if name != "q" {
+					continue
+				}
+
+				if parsed, err := strconv.ParseFloat(val, 64); err == nil {
+					q = parsed
+				}
+			}
+
+			switch strings.ToLower(strings.TrimSpace(mediaType)) {
+			case ProblemContentType:
+				problemQ = max(problemQ, q)
+			case "application/json":
+				jsonQ = max(jsonQ, q)
+			}
+		}
+	}
+
+	return problemQ > 0 && problemQ >= jsonQ
+}
+
+// writeError writes the error in the format negotiated with the client: problem details
+// or legacy {"error": {...}} object. Problem details of not found errors name the entity.
+func writeError(w http.ResponseWriter, r *http.Request, httpCode int, details ErrorDetails, cause error) {
+	if !AcceptsProblem(r) {
+		WriteResponse(w, httpCode, ErrorResponse{Details: details})
+		return
+	}
+
+	problem := NewProblem(r, httpCode, details)
+
+	if entity, ok := notFoundEntities[cause]; ok {
+		problem.Entity = entity
+		problem.Detail = cause.Error()
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(httpCode)
+
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
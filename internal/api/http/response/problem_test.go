@@ -0,0 +1,64 @@
+package response
+
+import (
+	"avito-task/internal/repository"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptsProblem(t *testing.T) {
+	cases := map[string]bool{
+		"":                         false,
+		"*/*":                      false,
+		"application/json":         false,
+		"application/problem+json": true,
+		"application/json, application/problem+json":       true,
+		"application/json, application/problem+json;q=0.5": false,
+		"application/problem+json;q=0":                     false,
+	}
+
+	for accept, want := range cases {
+		r := httptest.NewRequest(http.MethodGet, "/team/get", nil)
+		r.Header.Set("Accept", accept)
+
+		require.Equal(t, want, AcceptsProblem(r), accept)
+	}
+}
+
+func TestProcessErrorProblem(t *testing.T) {
+	err := fmt.Errorf("TeamService.GetTeam: %w", repository.ErrTeamNotExists)
+
+	r := httptest.NewRequest(http.MethodGet, "/team/get?team_name=x", nil)
+	r.Header.Set("Accept", ProblemContentType)
+
+	rec := httptest.NewRecorder()
+	ProcessError(rec, r, err)
+
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+
+	var problem Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	require.Equal(t, Problem{
+		Type:     "urn:avito-task:problem:not-found",
+		Title:    "Resource not found",
+		Status:   http.StatusNotFound,
+		Detail:   repository.ErrTeamNotExists.Error(),
+		Instance: "/team/get",
+		Code:     "NOT_FOUND",
+		Entity:   "team",
+	}, problem)
+
+	r.Header.Del("Accept")
+
+	rec = httptest.NewRecorder()
+	ProcessError(rec, r, err)
+
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.JSONEq(t, `{"error":{"code":"NOT_FOUND","message":"resource not found"}}`, rec.Body.String())
+}
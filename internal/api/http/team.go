@@ -4,6 +4,7 @@ import (
 	"avito-task/internal/api/http/response"
 	"avito-task/internal/api/http/types"
 	"avito-task/internal/config"
+	"avito-task/internal/domain"
 	"avito-task/internal/usecases"
 	"avito-task/pkg/http/handlers"
 	"net/http"
@@ -28,23 +29,23 @@ func NewTeamHandler(
 
 func (h *TeamHandler) WithTeamHandlers() handlers.RouterOption {
 	return func (r chi.Router) {
-		r.Post(h.pathCfg.AddTeam, h.addHandler)
-		r.Get(h.pathCfg.GetTeam, h.getHandler)
-		r.Get(h.pathCfg.GetTeamStats, h.getStatsHandler)
-		r.Post(h.pathCfg.DeactivateTeam, h.deactivateHandler)
+		r.With(requireRoles(domain.AccessAdmin)).Post(h.pathCfg.AddTeam, h.addHandler)
+		r.With(requireRoles(anyRole...)).Get(h.pathCfg.GetTeam, h.getHandler)
+		r.With(requireRoles(anyRole...)).Get(h.pathCfg.GetTeamStats, h.getStatsHandler)
+		r.With(requireRoles(managerRoles...)).Post(h.pathCfg.DeactivateTeam, h.deactivateHandler)
 	}
 }
 
 func (h *TeamHandler) addHandler(w http.ResponseWriter, r *http.Request) {
 	req, err := types.CreateAddTeamRequest(r)
 	if err != nil {
-		response.ProcessCreatingRequestError(w, err)
+		response.ProcessCreatingRequestError(w, r, err)
 		return
 	}
 
 	res, err := h.teamSvc.CreateTeam(r.Context(), req.Team)
 	if err != nil {
-		response.ProcessError(w, err)
+		response.ProcessError(w, r, err)
 		return
 	}
 
@@ -54,13 +55,13 @@ func (h *TeamHandler) addHandler(w http.ResponseWriter, r *http.Request) {
 func (h *TeamHandler) getHandler(w http.ResponseWriter, r *http.Request) {
 	req, err := types.CreateGetTeamRequest(r)
 	if err != nil {
-		response.ProcessCreatingRequestError(w, err)
+		response.ProcessCreatingRequestError(w, r, err)
 		return
 	}
 
 	res, err := h.teamSvc.GetTeam(r.Context(), req.Name)
 	if err != nil {
-		response.ProcessError(w, err)
+		response.ProcessError(w, r, err)
 		return
 	}
 
@@ -70,13 +71,13 @@ func (h *TeamHandler) getHandler(w http.ResponseWriter, r *http.Request) {
 func (h *TeamHandler) getStatsHandler(w http.ResponseWriter, r *http.Request) {
 	req, err := types.CreateGetTeamStatsRequest(r)
 	if err != nil {
-		response.ProcessCreatingRequestError(w, err)
+		response.ProcessCreatingRequestError(w, r, err)
 		return
 	}
 
 	res, err := h.teamSvc.GetTeamStats(r.Context(), req.Name)
 	if err != nil {
-		response.ProcessError(w, err)
+		response.ProcessError(w, r, err)
 		return
 	}
 
@@ -86,13 +87,13 @@ func (h *TeamHandler) getStatsHandler(w http.ResponseWriter, r *http.Request) {
 func (h *TeamHandler) deactivateHandler(w http.ResponseWriter, r *http.Request) {
 	req, err := types.CreateDeactivateTeamRequest(r)
 	if err != nil {
-		response.ProcessCreatingRequestError(w, err)
+		response.ProcessCreatingRequestError(w, r, err)
 		return
 	}
 
 	res, err := h.teamSvc.DeactivateTeam(r.Context(), req.Name)
 	if err != nil {
-		response.ProcessError(w, err)
+		response.ProcessError(w, r, err)
 		return
 	}
 
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"avito-task/internal/domain"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 500
+)
+
+var ErrInvalidQueryParam = errors.New("invalid query parameter (check limit, cursor and RFC 3339 timestamps)")
+
+// Requests --------------------------------------------------
+
+type GetAuditRequest struct {
+	Filter domain.AuditFilter
+}
+
+func parseTimeParam(r *http.Request, name string) (*time.Time, error) {
+	val := r.URL.Query().Get(name)
+	if len(val) == 0 {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		return nil, ErrInvalidQueryParam
+	}
+
+	return &t, nil
+}
+
+func CreateGetAuditRequest(r *http.Request) (*GetAuditRequest, error) {
+	const op = "CreateGetAuditRequest"
+
+	q := r.URL.Query()
+	req := GetAuditRequest{Filter: domain.AuditFilter{
+		ActorID:    q.Get("actor_id"),
+		Action:     domain.AuditAction(q.Get("action")),
+		TargetType: domain.AuditTarget(q.Get("target_type")),
+		TargetID:   q.Get("target_id"),
+		RequestID:  q.Get("request_id"),
+		Limit:      defaultAuditLimit,
+	}}
+
+	var err error
+
+	if req.Filter.From, err = parseTimeParam(r, "from"); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if req.Filter.To, err = parseTimeParam(r, "to"); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if val := q.Get("limit"); len(val) > 0 {
+		limit, err := strconv.Atoi(val)
+		if err != nil || limit <= 0 || limit > maxAuditLimit {
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidQueryParam)
+		}
+
+		req.Filter.Limit = limit
+	}
+
+	if val := q.Get("cursor"); len(val) > 0 {
+		cursor, err := strconv.ParseInt(val, 10, 64)
+		if err != nil || cursor <= 0 {
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidQueryParam)
+		}
+
+		req.Filter.BeforeID = cursor
+	}
+
+	return &req, nil
+}
+
+// Responses -------------------------------------------------
+
+type GetAuditResponse struct {
+	Entries    []*domain.AuditEntry `json:"entries"`
+	NextCursor int64                `json:"next_cursor,omitempty"`
+}
+
+func CreateGetAuditResponse(entries []*domain.AuditEntry, limit int) *GetAuditResponse {
+	res := GetAuditResponse{Entries: entries}
+
+	if len(entries) == limit {
+		res.NextCursor = entries[len(entries)-1].ID
+	}
+
+	return &res
+}
@@ -0,0 +1,88 @@
+package types
+
+import (
+	"avito-task/internal/api/validation"
+	"avito-task/internal/domain"
+	"fmt"
+	"net/http"
+)
+
+// Requests --------------------------------------------------
+
+type IssueAPIKeyRequest struct {
+	Key *domain.APIKey
+}
+
+func CreateIssueAPIKeyRequest(r *http.Request) (*IssueAPIKeyRequest, error) {
+	const op = "CreateIssueAPIKeyRequest"
+
+	var key domain.APIKey
+	if err := decodeJSON(r, &key); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var v validation.Validator
+	v.Name("name", key.Name)
+
+	if v.Required("role", string(key.Role)) && !key.Role.IsValid() {
+		v.Add("role", "%s", ErrInvalidAccessRole)
+	}
+
+	if key.Role.IsTeamScoped() {
+		v.ID("team_name", key.TeamName)
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &IssueAPIKeyRequest{Key: &domain.APIKey{
+		Name:     key.Name,
+		Role:     key.Role,
+		TeamName: key.TeamName,
+	}}, nil
+}
+
+type RevokeAPIKeyRequest struct {
+	ID string `json:"id"`
+}
+
+func CreateRevokeAPIKeyRequest(r *http.Request) (*RevokeAPIKeyRequest, error) {
+	const op = "CreateRevokeAPIKeyRequest"
+
+	var req RevokeAPIKeyRequest
+	if err := decodeJSON(r, &req); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var v validation.Validator
+	v.ID("id", req.ID)
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &req, nil
+}
+
+// Responses -------------------------------------------------
+
+type IssueAPIKeyResponse struct {
+	Key    string         `json:"key"`
+	APIKey *domain.APIKey `json:"api_key"`
+}
+
+func CreateIssueAPIKeyResponse(rawKey string, key *domain.APIKey) *IssueAPIKeyResponse {
+	return &IssueAPIKeyResponse{
+		Key:    rawKey,
+		APIKey: key,
+	}
+}
+
+type RevokeAPIKeyResponse struct {
+	APIKey *domain.APIKey `json:"api_key"`
+}
+
+func CreateRevokeAPIKeyResponse(key *domain.APIKey) *RevokeAPIKeyResponse {
+	return &RevokeAPIKeyResponse{APIKey: key}
+}
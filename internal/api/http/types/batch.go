@@ -0,0 +1,173 @@
+package types
+
+import (
+	"avito-task/internal/api/validation"
+	"avito-task/internal/domain"
+	"avito-task/internal/usecases"
+	"fmt"
+	"net/http"
+)
+
+// Requests --------------------------------------------------
+
+// batchOperation holds parameters of every operation type, they are named as in
+// the bodies of the corresponding endpoints; if_version replaces If-Match header.
+type batchOperation struct {
+	Op              usecases.BatchOpType `json:"op"`
+	PullRequestID   string               `json:"pull_request_id"`
+	PullRequestName string               `json:"pull_request_name"`
+	AuthorID        string               `json:"author_id"`
+	OldReviewerID   string               `json:"old_reviewer_id"`
+	UserID          string               `json:"user_id"`
+	IsActive        *bool                `json:"is_active"`
+	IfVersion       int64                `json:"if_version"`
+}
+
+type BatchRequest struct {
+	Atomic     bool
+	Operations []*usecases.BatchOperation
+}
+
+func CreateBatchRequest(r *http.Request, maxOperations int) (*BatchRequest, error) {
+	const op = "CreateBatchRequest"
+
+	var body struct {
+		Atomic     bool              `json:"atomic"`
+		Operations []*batchOperation `json:"operations"`
+	}
+
+	if err := decodeJSON(r, &body); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var v validation.Validator
+
+	switch {
+	case len(body.Operations) == 0:
+		v.Add("operations", "must contain at least one operation")
+	case len(body.Operations) > maxOperations:
+		v.Add("operations", "must contain at most %d operations", maxOperations)
+	}
+
+	req := BatchRequest{
+		Atomic:     body.Atomic,
+		Operations: make([]*usecases.BatchOperation, 0, len(body.Operations)),
+	}
+
+	for i, item := range body.Operations {
+		field := fmt.Sprintf("operations.%d", i)
+
+		if item == nil {
+			v.Add(field, "must be an object")
+			continue
+		}
+
+		batchOp := usecases.BatchOperation{
+			Type:      item.Op,
+			PRID:      item.PullRequestID,
+			IfVersion: item.IfVersion,
+		}
+
+		if item.IfVersion < 0 {
+			v.Add(field+".if_version", "must not be negative")
+		}
+
+		switch item.Op {
+		case usecases.BatchCreatePR:
+			v.ID(field+".pull_request_id", item.PullRequestID)
+			v.Name(field+".pull_request_name", item.PullRequestName)
+			v.ID(field+".author_id", item.AuthorID)
+
+			batchOp.PR = &domain.PullRequest{
+				ID:       item.PullRequestID,
+				Name:     item.PullRequestName,
+				AuthorID: item.AuthorID,
+			}
+
+		case usecases.BatchMergePR:
+			v.ID(field+".pull_request_id", item.PullRequestID)
+
+		case usecases.BatchReassign:
+			v.ID(field+".pull_request_id", item.PullRequestID)
+			v.ID(field+".old_reviewer_id", item.OldReviewerID)
+			batchOp.UserID = item.OldReviewerID
+
+		case usecases.BatchSetIsActive:
+			v.ID(field+".user_id", item.UserID)
+			batchOp.UserID = item.UserID
+
+			if item.IsActive == nil {
+				v.Add(field+".is_active", "is required")
+			} else {
+				batchOp.IsActive = *item.IsActive
+			}
+
+		default:
+			v.Add(field+".op", "must be one of create_pr, merge_pr, reassign, set_is_active")
+		}
+
+		req.Operations = append(req.Operations, &batchOp)
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &req, nil
+}
+
+// Responses -------------------------------------------------
+
+type BatchItemError struct {
+	StrCode string `json:"code"`
+	Message string `json:"message"`
+}
+
+// BatchItemResult has the body the operation endpoint would respond with or the error.
+type BatchItemResult struct {
+	Index  int                  `json:"index"`
+	Op     usecases.BatchOpType `json:"op"`
+	Status int                  `json:"status"`
+	Result any                  `json:"result,omitempty"`
+	Error  *BatchItemError      `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	Atomic    bool               `json:"atomic"`
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	Results   []*BatchItemResult `json:"results"`
+}
+
+// CreateBatchItemResult renders successful result of the operation.
+func CreateBatchItemResult(index int, op *usecases.BatchOperation, res *usecases.BatchResult) *BatchItemResult {
+	item := BatchItemResult{Index: index, Op: op.Type, Status: http.StatusOK}
+
+	switch op.Type {
+	case usecases.BatchCreatePR:
+		item.Status = http.StatusCreated
+		item.Result = MakeCreatePRResponse(res.PR)
+	case usecases.BatchMergePR:
+		item.Result = CreateMergePRResponse(res.PR)
+	case usecases.BatchReassign:
+		item.Result = CreateReassignResponse(res.ReplacedBy, res.PR)
+	case usecases.BatchSetIsActive:
+		item.Result = CreateSetIsActiveResponse(res.User)
+	}
+
+	return &item
+}
+
+func CreateBatchResponse(atomic bool, items []*BatchItemResult) *BatchResponse {
+	res := BatchResponse{Atomic: atomic, Results: items}
+
+	for _, item := range items {
+		if item.Error == nil {
+			res.Succeeded++
+		} else {
+			res.Failed++
+		}
+	}
+
+	return &res
+}
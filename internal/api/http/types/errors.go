@@ -4,4 +4,7 @@ import "errors"
 
 var (
 	ErrRequiredFieldMissing = errors.New("some required field is missing (probably name or id)")
+	ErrInvalidRole = errors.New("unknown user role (allowed: junior, middle, senior, lead)")
+	ErrInvalidAccessRole = errors.New("unknown access role (allowed: admin, team-lead, member, integration)")
+	ErrInvalidIfMatch = errors.New(`If-Match must be "*" or a single ETag returned for the PR`)
 )
@@ -0,0 +1,267 @@
+package types
+
+import (
+	"avito-task/internal/api/validation"
+	"avito-task/internal/domain"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exportCSVColumns are CSV headers of entities; export uses the same formats as import.
+var exportCSVColumns = map[domain.ExportEntity][]string{
+	domain.ExportTeams:        {"team_name", "required_reviewer_roles", "updated_at"},
+	domain.ExportUsers:        {"user_id", "username", "team_name", "is_active", "role", "updated_at"},
+	domain.ExportPullRequests: {"pull_request_id", "pull_request_name", "author_id", "status", "created_at", "merged_at", "version", "updated_at"},
+	domain.ExportReviews:      {"pull_request_id", "user_id", "updated_at"},
+}
+
+// Requests --------------------------------------------------
+
+type ExportRequest struct {
+	Format   ImportFormat
+	Entities []domain.ExportEntity
+	Filter   domain.ExportFilter
+}
+
+// CreateExportRequest reads format (ndjson by default), comma-separated entities (all by default,
+// CSV needs exactly one), team and updated_since (RFC 3339) query parameters.
+func CreateExportRequest(r *http.Request) (*ExportRequest, error) {
+	const op = "CreateExportRequest"
+
+	var v validation.Validator
+
+	q := r.URL.Query()
+	req := ExportRequest{
+		Format:   ImportFormatNDJSON,
+		Entities: domain.ExportEntities,
+		Filter:   domain.ExportFilter{TeamName: q.Get("team")},
+	}
+
+	switch format := ImportFormat(q.Get("format")); format {
+	case "":
+	case ImportFormatCSV, ImportFormatNDJSON:
+		req.Format = format
+	default:
+		v.Add("format", "must be csv or ndjson")
+	}
+
+	if val := q.Get("entity"); len(val) > 0 {
+		req.Entities = nil
+
+		for e := range strings.SplitSeq(val, ",") {
+			entity := domain.ExportEntity(strings.TrimSpace(e))
+
+			switch {
+			case !slices.Contains(domain.ExportEntities, entity):
+				v.Add("entity", "unknown entity %q, expected one of teams, users, pull_requests, reviews", entity)
+			case slices.Contains(req.Entities, entity):
+				v.Add("entity", "entity %q is listed twice", entity)
+			default:
+				req.Entities = append(req.Entities, entity)
+			}
+		}
+	}
+
+	if req.Format == ImportFormatCSV && len(req.Entities) != 1 {
+		v.Add("entity", "exactly one entity must be selected for CSV")
+	}
+
+	if q.Has("team") {
+		v.ID("team", req.Filter.TeamName)
+	}
+
+	if val := q.Get("updated_since"); len(val) > 0 {
+		if t, err := time.Parse(time.RFC3339, val); err != nil {
+			v.Add("updated_since", "must be RFC 3339 timestamp, e.g. 2025-10-24T12:34:56Z")
+		} else {
+			req.Filter.UpdatedSince = &t
+		}
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &req, nil
+}
+
+// Responses -------------------------------------------------
+
+type ExportTeam struct {
+	Entity        domain.ExportEntity `json:"entity"`
+	TeamName      string              `json:"team_name"`
+	RequiredRoles []domain.UserRole   `json:"required_reviewer_roles"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+type ExportUser struct {
+	Entity    domain.ExportEntity `json:"entity"`
+	UserID    string              `json:"user_id"`
+	Username  string              `json:"username"`
+	TeamName  string              `json:"team_name"`
+	IsActive  bool                `json:"is_active"`
+	Role      domain.UserRole     `json:"role"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+type ExportPullRequest struct {
+	Entity          domain.ExportEntity `json:"entity"`
+	PullRequestID   string              `json:"pull_request_id"`
+	PullRequestName string              `json:"pull_request_name"`
+	AuthorID        string              `json:"author_id"`
+	Status          domain.PRStatus     `json:"status"`
+	CreatedAt       *time.Time          `json:"created_at"`
+	MergedAt        *time.Time          `json:"merged_at"`
+	Version         int64               `json:"version"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+}
+
+type ExportReview struct {
+	Entity        domain.ExportEntity `json:"entity"`
+	PullRequestID string              `json:"pull_request_id"`
+	UserID        string              `json:"user_id"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+// CreateExportRow renders the record as one NDJSON line, times are in UTC.
+func CreateExportRow(rec *domain.ExportRecord) any {
+	updatedAt := rec.UpdatedAt.UTC()
+
+	switch rec.Entity {
+	case domain.ExportTeams:
+		roles := rec.Team.RequiredRoles
+		if roles == nil {
+			roles = []domain.UserRole{}
+		}
+
+		return &ExportTeam{
+			Entity:        rec.Entity,
+			TeamName:      rec.Team.Name,
+			RequiredRoles: roles,
+			UpdatedAt:     updatedAt,
+		}
+	case domain.ExportUsers:
+		return &ExportUser{
+			Entity:    rec.Entity,
+			UserID:    rec.User.ID,
+			Username:  rec.User.Name,
+			TeamName:  rec.User.TeamName,
+			IsActive:  rec.User.IsActive,
+			Role:      rec.User.Role,
+			UpdatedAt: updatedAt,
+		}
+	case domain.ExportPullRequests:
+		return &ExportPullRequest{
+			Entity:          rec.Entity,
+			PullRequestID:   rec.PR.ID,
+			PullRequestName: rec.PR.Name,
+			AuthorID:        rec.PR.AuthorID,
+			Status:          rec.PR.Status,
+			CreatedAt:       utcTime(rec.PR.CreatedAt),
+			MergedAt:        utcTime(rec.PR.MergedAt),
+			Version:         rec.PR.Version,
+			UpdatedAt:       updatedAt,
+		}
+	default:
+		return &ExportReview{
+			Entity:        rec.Entity,
+			PullRequestID: rec.Review.PullRequestID,
+			UserID:        rec.Review.UserID,
+			UpdatedAt:     updatedAt,
+		}
+	}
+}
+
+func utcTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	utc := t.UTC()
+
+	return &utc
+}
+
+// ExportWriter renders exported records in the requested format.
+type ExportWriter struct {
+	enc *json.Encoder
+	csv *csv.Writer
+}
+
+// NewExportWriter creates the writer of the request, CSV header is written at once,
+// so that empty export still names its columns.
+func NewExportWriter(w io.Writer, req *ExportRequest) (*ExportWriter, error) {
+	if req.Format == ImportFormatNDJSON {
+		return &ExportWriter{enc: json.NewEncoder(w)}, nil
+	}
+
+	ew := ExportWriter{csv: csv.NewWriter(w)}
+
+	if err := ew.csv.Write(exportCSVColumns[req.Entities[0]]); err != nil {
+		return nil, err
+	}
+
+	return &ew, nil
+}
+
+func (ew *ExportWriter) Write(rec *domain.ExportRecord) error {
+	if ew.enc != nil {
+		return ew.enc.Encode(CreateExportRow(rec))
+	}
+
+	return ew.csv.Write(exportCSVRow(rec))
+}
+
+// Flush writes buffered CSV rows to the underlying writer.
+func (ew *ExportWriter) Flush() error {
+	if ew.csv == nil {
+		return nil
+	}
+
+	ew.csv.Flush()
+
+	return ew.csv.Error()
+}
+
+func exportCSVRow(rec *domain.ExportRecord) []string {
+	updatedAt := formatCSVTime(&rec.UpdatedAt)
+
+	switch rec.Entity {
+	case domain.ExportTeams:
+		roles := make([]string, 0, len(rec.Team.RequiredRoles))
+		for _, role := range rec.Team.RequiredRoles {
+			roles = append(roles, string(role))
+		}
+
+		return []string{rec.Team.Name, strings.Join(roles, csvListSep), updatedAt}
+	case domain.ExportUsers:
+		u := rec.User
+
+		return []string{u.ID, u.Name, u.TeamName, strconv.FormatBool(u.IsActive), string(u.Role), updatedAt}
+	case domain.ExportPullRequests:
+		pr := rec.PR
+
+		return []string{
+			pr.ID, pr.Name, pr.AuthorID, string(pr.Status),
+			formatCSVTime(pr.CreatedAt), formatCSVTime(pr.MergedAt),
+			strconv.FormatInt(pr.Version, 10), updatedAt,
+		}
+	default:
+		return []string{rec.Review.PullRequestID, rec.Review.UserID, updatedAt}
+	}
+}
+
+func formatCSVTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339Nano)
+}
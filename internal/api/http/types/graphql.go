@@ -0,0 +1,30 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Requests --------------------------------------------------
+
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+func CreateGraphQLRequest(r *http.Request) (*GraphQLRequest, error) {
+	const op = "CreateGraphQLRequest"
+
+	var req GraphQLRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(req.Query) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, ErrRequiredFieldMissing)
+	}
+
+	return &req, nil
+}
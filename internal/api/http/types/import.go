@@ -0,0 +1,397 @@
+package types
+
+import (
+	"avito-task/internal/api/validation"
+	"avito-task/internal/domain"
+	"avito-task/internal/usecases"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ImportFormat string
+
+const (
+	ImportFormatCSV    ImportFormat = "csv"
+	ImportFormatNDJSON ImportFormat = "ndjson"
+)
+
+// csvListSep separates items of list columns of CSV (reviewers, roles).
+const csvListSep = ";"
+
+var (
+	ErrUnknownImportFormat = errors.New("unknown import format, expected csv or ndjson")
+	ErrTooManyRecords      = errors.New("too many records in import file")
+
+	importColumns         = []string{"pull_request_id", "pull_request_name", "author_id", "status", "reviewers", "created_at", "merged_at"}
+	requiredImportColumns = []string{"pull_request_id", "pull_request_name", "author_id"}
+)
+
+// ImportFormatFromContentType detects format by media type: text/csv or application/x-ndjson.
+func ImportFormatFromContentType(contentType string) (ImportFormat, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch mediaType {
+	case "text/csv":
+		return ImportFormatCSV, nil
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return ImportFormatNDJSON, nil
+	}
+
+	return "", ErrUnknownImportFormat
+}
+
+// ImportFormatFromPath detects format by file extension: .csv, .ndjson or .jsonl.
+func ImportFormatFromPath(path string) (ImportFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return ImportFormatCSV, nil
+	case ".ndjson", ".jsonl":
+		return ImportFormatNDJSON, nil
+	}
+
+	return "", ErrUnknownImportFormat
+}
+
+// importFields are fields of the import record as they are written in the file.
+type importFields struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        string    `json:"author_id"`
+	Status          string    `json:"status"`
+	Reviewers       *[]string `json:"reviewers"`
+	CreatedAt       string    `json:"created_at"`
+	MergedAt        string    `json:"merged_at"`
+}
+
+// record validates fields and converts them to the record of given line.
+func (f *importFields) record(line int) *usecases.ImportRecord {
+	var v validation.Validator
+
+	v.ID("pull_request_id", f.PullRequestID)
+	v.Name("pull_request_name", f.PullRequestName)
+	v.ID("author_id", f.AuthorID)
+
+	rec := usecases.ImportRecord{
+		Line: line,
+		PR: &domain.PullRequest{
+			ID:       f.PullRequestID,
+			Name:     f.PullRequestName,
+			AuthorID: f.AuthorID,
+			Status:   domain.PRStatus(strings.ToUpper(f.Status)),
+		},
+		HasReviewers: f.Reviewers != nil,
+	}
+
+	switch rec.PR.Status {
+	case "":
+		rec.PR.Status = domain.PROpen
+	case domain.PROpen, domain.PRMerged:
+	default:
+		v.Add("status", "must be OPEN or MERGED")
+	}
+
+	if f.Reviewers != nil {
+		rec.PR.Reviewers = *f.Reviewers
+
+		for i, id := range rec.PR.Reviewers {
+			v.ID(fmt.Sprintf("reviewers.%d", i), id)
+		}
+	}
+
+	rec.PR.CreatedAt = parseImportTime(&v, "created_at", f.CreatedAt)
+	rec.PR.MergedAt = parseImportTime(&v, "merged_at", f.MergedAt)
+
+	for _, fe := range v.Fields() {
+		rec.Problems = append(rec.Problems, usecases.ImportProblem{Field: fe.Field, Message: fe.Message})
+	}
+
+	return &rec
+}
+
+// parseImportTime parses optional RFC 3339 timestamp.
+func parseImportTime(v *validation.Validator, field, val string) *time.Time {
+	if len(val) == 0 {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, val)
+	if err != nil {
+		v.Add(field, "must be RFC 3339 timestamp, e.g. 2025-10-24T12:34:56Z")
+		return nil
+	}
+
+	t = t.UTC()
+
+	return &t
+}
+
+// ReadImportRecords reads PRs to import. CSV must start with the header naming columns,
+// reviewers are separated by ';'; NDJSON has one object per line. Problems of separate
+// records are reported in them, the error is returned if the file can not be read at all.
+func ReadImportRecords(r io.Reader, format ImportFormat, maxRecords int) ([]*usecases.ImportRecord, error) {
+	const op = "ReadImportRecords"
+
+	var (
+		records []*usecases.ImportRecord
+		err     error
+	)
+
+	switch format {
+	case ImportFormatCSV:
+		records, err = readImportCSV(r, maxRecords)
+	case ImportFormatNDJSON:
+		records, err = readImportNDJSON(r, maxRecords)
+	default:
+		err = ErrUnknownImportFormat
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s: import file contains no records", op)
+	}
+
+	return records, nil
+}
+
+func readImportCSV(r io.Reader, maxRecords int) ([]*usecases.ImportRecord, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("invalid CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+
+		if !slices.Contains(importColumns, name) {
+			return nil, fmt.Errorf("unknown CSV column %q, expected %s", name, strings.Join(importColumns, ", "))
+		}
+
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("CSV column %q is repeated", name)
+		}
+
+		columns[name] = i
+	}
+
+	for _, name := range requiredImportColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("required CSV column %q is missing", name)
+		}
+	}
+
+	var records []*usecases.ImportRecord
+
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		if len(records) == maxRecords {
+			return nil, fmt.Errorf("%w: at most %d are allowed", ErrTooManyRecords, maxRecords)
+		}
+
+		line, _ := cr.FieldPos(0)
+
+		get := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(row[i])
+			}
+
+			return ""
+		}
+
+		fields := importFields{
+			PullRequestID:   get("pull_request_id"),
+			PullRequestName: row[columns["pull_request_name"]],
+			AuthorID:        get("author_id"),
+			Status:          get("status"),
+			CreatedAt:       get("created_at"),
+			MergedAt:        get("merged_at"),
+		}
+
+		if _, ok := columns["reviewers"]; ok {
+			reviewers := []string{}
+
+			for id := range strings.SplitSeq(get("reviewers"), csvListSep) {
+				if id = strings.TrimSpace(id); len(id) > 0 {
+					reviewers = append(reviewers, id)
+				}
+			}
+
+			fields.Reviewers = &reviewers
+		}
+
+		records = append(records, fields.record(line))
+	}
+}
+
+func readImportNDJSON(r io.Reader, maxRecords int) ([]*usecases.ImportRecord, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var records []*usecases.ImportRecord
+
+	for line := 1; sc.Scan(); line++ {
+		data := bytes.TrimSpace(sc.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		if len(records) == maxRecords {
+			return nil, fmt.Errorf("%w: at most %d are allowed", ErrTooManyRecords, maxRecords)
+		}
+
+		var fields importFields
+
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+
+		if err := dec.Decode(&fields); err != nil || dec.More() {
+			if err == nil {
+				err = errors.New("line must contain a single JSON object")
+			}
+
+			records = append(records, &usecases.ImportRecord{
+				Line:     line,
+				PR:       &domain.PullRequest{},
+				Problems: []usecases.ImportProblem{{Field: "record", Message: err.Error()}},
+			})
+
+			continue
+		}
+
+		records = append(records, fields.record(line))
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("invalid NDJSON: %w", err)
+	}
+
+	return records, nil
+}
+
+// Requests --------------------------------------------------
+
+type ImportPRRequest struct {
+	DryRun  bool
+	Records []*usecases.ImportRecord
+}
+
+func CreateImportPRRequest(r *http.Request, maxRecords int) (*ImportPRRequest, error) {
+	const op = "CreateImportPRRequest"
+
+	var req ImportPRRequest
+
+	if q := r.URL.Query().Get("dry_run"); len(q) > 0 {
+		dryRun, err := strconv.ParseBool(q)
+		if err != nil {
+			return nil, fmt.Errorf("%s: dry_run must be true or false", op)
+		}
+
+		req.DryRun = dryRun
+	}
+
+	format, err := ImportFormatFromContentType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if req.Records, err = ReadImportRecords(r.Body, format, maxRecords); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &req, nil
+}
+
+// Responses -------------------------------------------------
+
+type ImportProblem struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type ImportItem struct {
+	Line              int                   `json:"line"`
+	PullRequestID     string                `json:"pull_request_id,omitempty"`
+	Status            usecases.ImportStatus `json:"status"`
+	PRStatus          domain.PRStatus       `json:"pr_status,omitempty"`
+	Reviewers         []string              `json:"assigned_reviewers,omitempty"`
+	ReviewersAssigned bool                  `json:"reviewers_assigned,omitempty"`
+	CreatedAt         *time.Time            `json:"createdAt,omitempty"`
+	MergedAt          *time.Time            `json:"mergedAt,omitempty"`
+	Problems          []ImportProblem       `json:"problems,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun  bool          `json:"dry_run"`
+	Applied bool          `json:"applied"`
+	Total   int           `json:"total"`
+	Valid   int           `json:"valid"`
+	Invalid int           `json:"invalid"`
+	Items   []*ImportItem `json:"items"`
+}
+
+// CreateImportReport renders the report of import, it is shared by the endpoint and the command.
+func CreateImportReport(report *usecases.ImportReport) *ImportReport {
+	res := ImportReport{
+		DryRun:  report.DryRun,
+		Applied: report.Applied,
+		Total:   len(report.Items),
+		Valid:   report.Valid,
+		Invalid: report.Invalid,
+		Items:   make([]*ImportItem, 0, len(report.Items)),
+	}
+
+	for _, item := range report.Items {
+		it := ImportItem{
+			Line:              item.Line,
+			PullRequestID:     item.PR.ID,
+			Status:            item.Status,
+			ReviewersAssigned: item.ReviewersAssigned,
+		}
+
+		if item.Status != usecases.ImportStatusInvalid {
+			it.PRStatus = item.PR.Status
+			it.Reviewers = item.PR.Reviewers
+			it.CreatedAt = item.PR.CreatedAt
+			it.MergedAt = item.PR.MergedAt
+		}
+
+		for _, p := range item.Problems {
+			it.Problems = append(it.Problems, ImportProblem{Field: p.Field, Message: p.Message})
+		}
+
+		res.Items = append(res.Items, &it)
+	}
+
+	return &res
+}
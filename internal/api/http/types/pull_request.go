@@ -1,12 +1,39 @@
 package types
 
 import (
+	"avito-task/internal/api/validation"
 	"avito-task/internal/domain"
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
+// PRETag returns entity tag of the PR version.
+func PRETag(pr *domain.PullRequest) string {
+	return strconv.Quote(strconv.FormatInt(pr.Version, 10))
+}
+
+// parseIfMatch returns PR version required by If-Match header, 0 if any version is accepted.
+func parseIfMatch(r *http.Request) (int64, error) {
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if len(ifMatch) == 0 || ifMatch == "*" {
+		return 0, nil
+	}
+
+	tag, err := strconv.Unquote(ifMatch)
+	if err != nil || !strings.HasPrefix(ifMatch, `"`) {
+		return 0, ErrInvalidIfMatch
+	}
+
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, ErrInvalidIfMatch
+	}
+
+	return version, nil
+}
+
 // Requests --------------------------------------------------
 
 type CreatePRRequest struct {
@@ -17,12 +44,17 @@ func MakeCreatePRRequest(r *http.Request) (*CreatePRRequest, error) {
 	const op = "MakeCreatePRRequest"
 
 	var pr domain.PullRequest
-	if err := json.NewDecoder(r.Body).Decode(&pr); err != nil {
+	if err := decodeJSON(r, &pr); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if len(pr.ID) == 0 || len(pr.Name) == 0 || len(pr.AuthorID) == 0 {
-		return nil, fmt.Errorf("%s: %w", op, ErrRequiredFieldMissing)
+	var v validation.Validator
+	v.ID("pull_request_id", pr.ID)
+	v.Name("pull_request_name", pr.Name)
+	v.ID("author_id", pr.AuthorID)
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return &CreatePRRequest{PR: &pr}, nil
@@ -30,40 +62,63 @@ func MakeCreatePRRequest(r *http.Request) (*CreatePRRequest, error) {
 
 type MergePRRequest struct {
 	PRID string `json:"pull_request_id"`
+	IfVersion int64 `json:"-"`
 }
 
 func CreateMergePRRequest(r *http.Request) (*MergePRRequest, error) {
 	const op = "CreateMergePRRequest"
 
 	var req MergePRRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := decodeJSON(r, &req); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if len(req.PRID) == 0 {
-		return nil, fmt.Errorf("%s: %w", op, ErrRequiredFieldMissing)
+	var v validation.Validator
+	v.ID("pull_request_id", req.PRID)
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	req.IfVersion = version
+
 	return &req, nil
 }
 
 type ReassignRequest struct {
 	PRID string `json:"pull_request_id"`
 	OldRewID string `json:"old_reviewer_id"`
+	IfVersion int64 `json:"-"`
 }
 
 func CreateReassignRequest(r *http.Request) (*ReassignRequest, error) {
 	const op = "CreateReassignRequest"
 
 	var req ReassignRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := decodeJSON(r, &req); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if len(req.PRID) == 0 || len(req.OldRewID) == 0 {
-		return nil, fmt.Errorf("%s: %w", op, ErrRequiredFieldMissing)
+	var v validation.Validator
+	v.ID("pull_request_id", req.PRID)
+	v.ID("old_reviewer_id", req.OldRewID)
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	req.IfVersion = version
+
 	return &req, nil
 }
 
@@ -1,8 +1,8 @@
 package types
 
 import (
+	"avito-task/internal/api/validation"
 	"avito-task/internal/domain"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -18,20 +18,47 @@ func CreateAddTeamRequest(r *http.Request) (*AddTeamRequest, error) {
 
 	var team domain.Team
 
-	if err := json.NewDecoder(r.Body).Decode(&team); err != nil {
+	if err := decodeJSON(r, &team); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if len(team.Name) == 0 {
-		return nil, fmt.Errorf("%s: %w", op, ErrRequiredFieldMissing)
+	var v validation.Validator
+	v.ID("team_name", team.Name)
+
+	seen := make(map[string]int, len(team.Members))
+
+	for i, u := range team.Members {
+		if u == nil {
+			v.Add(fmt.Sprintf("members.%d", i), "must be an object")
+			continue
+		}
+
+		v.ID(fmt.Sprintf("members.%d.user_id", i), u.ID)
+		v.Name(fmt.Sprintf("members.%d.username", i), u.Name)
+
+		if first, ok := seen[u.ID]; ok && len(u.ID) > 0 {
+			v.Add(fmt.Sprintf("members.%d.user_id", i), "duplicates members.%d", first)
+		} else {
+			seen[u.ID] = i
+		}
+
+		if len(u.Role) == 0 {
+			u.Role = domain.RoleMiddle
+		} else if !u.Role.IsValid() {
+			v.Add(fmt.Sprintf("members.%d.role", i), "%s", ErrInvalidRole)
+		}
 	}
 
-	for _, u := range team.Members {
-		if len(u.ID) == 0 || len(u.Name) == 0 {
-			return nil, fmt.Errorf("%s: %w", op, ErrRequiredFieldMissing)
+	for i, role := range team.RequiredRoles {
+		if !role.IsValid() {
+			v.Add(fmt.Sprintf("required_reviewer_roles.%d", i), "%s", ErrInvalidRole)
 		}
 	}
 
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return &AddTeamRequest{Team: &team}, nil
 }
 
@@ -45,8 +72,11 @@ func CreateGetTeamRequest(r *http.Request) (*GetTeamRequest, error) {
 	var req GetTeamRequest
 	req.Name = r.URL.Query().Get("team_name")
 
-	if len(req.Name) == 0 {
-		return nil, fmt.Errorf("%s: %w", op, ErrRequiredFieldMissing)
+	var v validation.Validator
+	v.ID("team_name", req.Name)
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return &req, nil
@@ -62,8 +92,11 @@ func CreateGetTeamStatsRequest(r *http.Request) (*GetTeamStatsRequest, error) {
 	var req GetTeamStatsRequest
 	req.Name = r.URL.Query().Get("team_name")
 
-	if len(req.Name) == 0 {
-		return nil, fmt.Errorf("%s: %w", op, ErrRequiredFieldMissing)
+	var v validation.Validator
+	v.ID("team_name", req.Name)
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return &req, nil
@@ -79,8 +112,11 @@ func CreateDeactivateTeamRequest(r *http.Request) (*DeactivateTeamRequest, error
 	var req DeactivateTeamRequest
 	req.Name = r.URL.Query().Get("team_name")
 
-	if len(req.Name) == 0 {
-		return nil, fmt.Errorf("%s: %w", op, ErrRequiredFieldMissing)
+	var v validation.Validator
+	v.ID("team_name", req.Name)
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return &req, nil
@@ -1,8 +1,8 @@
 package types
 
 import (
+	"avito-task/internal/api/validation"
 	"avito-task/internal/domain"
-	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -18,12 +18,15 @@ func CreateSetIsActiveRequest(r *http.Request) (*SetIsActiveRequest, error) {
 	const op = "CreateSetIsActiveRequest"
 
 	var req SetIsActiveRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := decodeJSON(r, &req); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if len(req.UserID) == 0 {
-		return nil, fmt.Errorf("%s: %w", op, ErrRequiredFieldMissing)
+	var v validation.Validator
+	v.ID("user_id", req.UserID)
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return &req, nil
@@ -38,8 +41,11 @@ func CreateGetReviewRequest(r *http.Request) (*GetReviewRequest, error) {
 
 	req := GetReviewRequest{UserID: r.URL.Query().Get("user_id")}
 
-	if len(req.UserID) == 0 {
-		return nil, fmt.Errorf("%s: %w", op, ErrRequiredFieldMissing)
+	var v validation.Validator
+	v.ID("user_id", req.UserID)
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return &req, nil
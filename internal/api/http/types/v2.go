@@ -0,0 +1,439 @@
+package types
+
+import (
+	"avito-task/internal/api/validation"
+	"avito-task/internal/domain"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Requests and responses of /v2 resource-oriented API. Resources are identified by path,
+// so their representations use plain id and name fields and snake_case everywhere.
+
+// Resources -------------------------------------------------
+
+type UserV2 struct {
+	ID       string          `json:"id"`
+	Name     string          `json:"name"`
+	TeamName string          `json:"team_name,omitempty"`
+	IsActive bool            `json:"is_active"`
+	Role     domain.UserRole `json:"role"`
+}
+
+type TeamV2 struct {
+	Name                  string            `json:"name"`
+	Members               []*UserV2         `json:"members"`
+	RequiredReviewerRoles []domain.UserRole `json:"required_reviewer_roles"`
+}
+
+type PullRequestV2 struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	AuthorID  string          `json:"author_id"`
+	Status    domain.PRStatus `json:"status"`
+	Reviewers []string        `json:"reviewers"`
+	CreatedAt *time.Time      `json:"created_at"`
+	MergedAt  *time.Time      `json:"merged_at"`
+	Version   int64           `json:"version"`
+}
+
+type PullRequestShortV2 struct {
+	ID       string          `json:"id"`
+	Name     string          `json:"name"`
+	AuthorID string          `json:"author_id"`
+	Status   domain.PRStatus `json:"status"`
+}
+
+func MakeUserV2(u *domain.User) *UserV2 {
+	return &UserV2{
+		ID:       u.ID,
+		Name:     u.Name,
+		TeamName: u.TeamName,
+		IsActive: u.IsActive,
+		Role:     u.Role,
+	}
+}
+
+func makeUsersV2(users []*domain.User) []*UserV2 {
+	res := make([]*UserV2, 0, len(users))
+	for _, u := range users {
+		res = append(res, MakeUserV2(u))
+	}
+
+	return res
+}
+
+func MakeTeamV2(team *domain.Team) *TeamV2 {
+	res := TeamV2{
+		Name:                  team.Name,
+		Members:               makeUsersV2(team.Members),
+		RequiredReviewerRoles: team.RequiredRoles,
+	}
+
+	if res.RequiredReviewerRoles == nil {
+		res.RequiredReviewerRoles = []domain.UserRole{}
+	}
+
+	for _, u := range res.Members {
+		u.TeamName = ""
+	}
+
+	return &res
+}
+
+func MakePullRequestV2(pr *domain.PullRequest) *PullRequestV2 {
+	res := PullRequestV2{
+		ID:        pr.ID,
+		Name:      pr.Name,
+		AuthorID:  pr.AuthorID,
+		Status:    pr.Status,
+		Reviewers: pr.Reviewers,
+		CreatedAt: pr.CreatedAt,
+		MergedAt:  pr.MergedAt,
+		Version:   pr.Version,
+	}
+
+	if res.Reviewers == nil {
+		res.Reviewers = []string{}
+	}
+
+	return &res
+}
+
+// Requests --------------------------------------------------
+
+// pathID returns validated path parameter of the request.
+func pathID(r *http.Request, v *validation.Validator, param string) string {
+	val := chi.URLParam(r, param)
+	v.ID(param, val)
+
+	return val
+}
+
+type AddTeamV2Request struct {
+	Team *domain.Team
+}
+
+func CreateAddTeamV2Request(r *http.Request) (*AddTeamV2Request, error) {
+	const op = "CreateAddTeamV2Request"
+
+	var body struct {
+		Name    string `json:"name"`
+		Members []*struct {
+			ID       string          `json:"id"`
+			Name     string          `json:"name"`
+			IsActive *bool           `json:"is_active"`
+			Role     domain.UserRole `json:"role"`
+		} `json:"members"`
+		RequiredReviewerRoles []domain.UserRole `json:"required_reviewer_roles"`
+	}
+
+	if err := decodeJSON(r, &body); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var v validation.Validator
+	v.ID("name", body.Name)
+
+	team := domain.Team{
+		Name:          body.Name,
+		Members:       make([]*domain.User, 0, len(body.Members)),
+		RequiredRoles: body.RequiredReviewerRoles,
+	}
+
+	seen := make(map[string]int, len(body.Members))
+
+	for i, m := range body.Members {
+		if m == nil {
+			v.Add(fmt.Sprintf("members.%d", i), "must be an object")
+			continue
+		}
+
+		v.ID(fmt.Sprintf("members.%d.id", i), m.ID)
+		v.Name(fmt.Sprintf("members.%d.name", i), m.Name)
+
+		if first, ok := seen[m.ID]; ok && len(m.ID) > 0 {
+			v.Add(fmt.Sprintf("members.%d.id", i), "duplicates members.%d", first)
+		} else {
+			seen[m.ID] = i
+		}
+
+		u := domain.User{ID: m.ID, Name: m.Name, IsActive: true, Role: m.Role}
+
+		if m.IsActive != nil {
+			u.IsActive = *m.IsActive
+		}
+
+		if len(u.Role) == 0 {
+			u.Role = domain.RoleMiddle
+		} else if !u.Role.IsValid() {
+			v.Add(fmt.Sprintf("members.%d.role", i), "%s", ErrInvalidRole)
+		}
+
+		team.Members = append(team.Members, &u)
+	}
+
+	for i, role := range team.RequiredRoles {
+		if !role.IsValid() {
+			v.Add(fmt.Sprintf("required_reviewer_roles.%d", i), "%s", ErrInvalidRole)
+		}
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &AddTeamV2Request{Team: &team}, nil
+}
+
+// TeamV2Request addresses the team by /v2/teams/{name}.
+type TeamV2Request struct {
+	Name string
+}
+
+func CreateTeamV2Request(r *http.Request) (*TeamV2Request, error) {
+	const op = "CreateTeamV2Request"
+
+	var v validation.Validator
+	req := TeamV2Request{Name: pathID(r, &v, "name")}
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &req, nil
+}
+
+// UserV2Request addresses the user by /v2/users/{id}.
+type UserV2Request struct {
+	ID string
+}
+
+func CreateUserV2Request(r *http.Request) (*UserV2Request, error) {
+	const op = "CreateUserV2Request"
+
+	var v validation.Validator
+	req := UserV2Request{ID: pathID(r, &v, "id")}
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &req, nil
+}
+
+type UpdateUserV2Request struct {
+	ID       string
+	IsActive bool
+}
+
+func CreateUpdateUserV2Request(r *http.Request) (*UpdateUserV2Request, error) {
+	const op = "CreateUpdateUserV2Request"
+
+	var body struct {
+		IsActive *bool `json:"is_active"`
+	}
+
+	if err := decodeJSON(r, &body); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var v validation.Validator
+	req := UpdateUserV2Request{ID: pathID(r, &v, "id")}
+
+	if body.IsActive == nil {
+		v.Add("is_active", "is required")
+	} else {
+		req.IsActive = *body.IsActive
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &req, nil
+}
+
+type CreatePRV2Request struct {
+	PR *domain.PullRequest
+}
+
+func MakeCreatePRV2Request(r *http.Request) (*CreatePRV2Request, error) {
+	const op = "MakeCreatePRV2Request"
+
+	var body struct {
+		ID       string `json:"id"`
+		Name     string `json:"name"`
+		AuthorID string `json:"author_id"`
+	}
+
+	if err := decodeJSON(r, &body); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var v validation.Validator
+	v.ID("id", body.ID)
+	v.Name("name", body.Name)
+	v.ID("author_id", body.AuthorID)
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &CreatePRV2Request{PR: &domain.PullRequest{
+		ID:       body.ID,
+		Name:     body.Name,
+		AuthorID: body.AuthorID,
+	}}, nil
+}
+
+// PullRequestV2Request addresses the PR by /v2/pull-requests/{id}, IfVersion comes from If-Match.
+type PullRequestV2Request struct {
+	ID        string
+	IfVersion int64
+}
+
+func CreatePullRequestV2Request(r *http.Request) (*PullRequestV2Request, error) {
+	const op = "CreatePullRequestV2Request"
+
+	var v validation.Validator
+	req := PullRequestV2Request{ID: pathID(r, &v, "id")}
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	req.IfVersion = version
+
+	return &req, nil
+}
+
+type ReassignV2Request struct {
+	PullRequestV2Request
+	OldReviewerID string
+}
+
+func CreateReassignV2Request(r *http.Request) (*ReassignV2Request, error) {
+	const op = "CreateReassignV2Request"
+
+	var body struct {
+		OldReviewerID string `json:"old_reviewer_id"`
+	}
+
+	if err := decodeJSON(r, &body); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var v validation.Validator
+	v.ID("old_reviewer_id", body.OldReviewerID)
+
+	if err := v.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	pr, err := CreatePullRequestV2Request(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &ReassignV2Request{PullRequestV2Request: *pr, OldReviewerID: body.OldReviewerID}, nil
+}
+
+// Responses -------------------------------------------------
+
+type UserStatsV2 struct {
+	ID           string `json:"id"`
+	ReviewsCount int    `json:"open_reviews_count"`
+}
+
+type PullRequestStatsV2 struct {
+	ID             string          `json:"id"`
+	Status         domain.PRStatus `json:"status"`
+	ReviewersCount int             `json:"reviewers_count"`
+}
+
+type TeamStatsV2Response struct {
+	Name             string                `json:"name"`
+	Users            []*UserStatsV2        `json:"users"`
+	OpenPullRequests []*PullRequestStatsV2 `json:"open_pull_requests"`
+}
+
+func CreateTeamStatsV2Response(stats *domain.TeamStats) *TeamStatsV2Response {
+	res := TeamStatsV2Response{
+		Name:             stats.Name,
+		Users:            make([]*UserStatsV2, 0, len(stats.Users)),
+		OpenPullRequests: make([]*PullRequestStatsV2, 0, len(stats.PRs)),
+	}
+
+	for _, u := range stats.Users {
+		res.Users = append(res.Users, &UserStatsV2{ID: u.ID, ReviewsCount: u.ReviewsCount})
+	}
+
+	for _, pr := range stats.PRs {
+		res.OpenPullRequests = append(res.OpenPullRequests, &PullRequestStatsV2{
+			ID:             pr.ID,
+			Status:         pr.Status,
+			ReviewersCount: pr.ReviewersCount,
+		})
+	}
+
+	return &res
+}
+
+type DeactivateTeamV2Response struct {
+	TeamName string    `json:"team_name"`
+	Users    []*UserV2 `json:"deactivated_users"`
+}
+
+func CreateDeactivateTeamV2Response(name string, users []*domain.User) *DeactivateTeamV2Response {
+	res := DeactivateTeamV2Response{TeamName: name, Users: makeUsersV2(users)}
+
+	for _, u := range res.Users {
+		u.TeamName = ""
+	}
+
+	return &res
+}
+
+type UserReviewsV2Response struct {
+	UserID       string                `json:"user_id"`
+	PullRequests []*PullRequestShortV2 `json:"pull_requests"`
+}
+
+func CreateUserReviewsV2Response(id string, prs []*domain.PullRequestShort) *UserReviewsV2Response {
+	res := UserReviewsV2Response{
+		UserID:       id,
+		PullRequests: make([]*PullRequestShortV2, 0, len(prs)),
+	}
+
+	for _, pr := range prs {
+		res.PullRequests = append(res.PullRequests, &PullRequestShortV2{
+			ID:       pr.ID,
+			Name:     pr.Name,
+			AuthorID: pr.AuthorID,
+			Status:   pr.Status,
+		})
+	}
+
+	return &res
+}
+
+type ReassignV2Response struct {
+	ReplacedBy  string         `json:"replaced_by"`
+	PullRequest *PullRequestV2 `json:"pull_request"`
+}
+
+func CreateReassignV2Response(newRewID string, pr *domain.PullRequest) *ReassignV2Response {
+	return &ReassignV2Response{
+		ReplacedBy:  newRewID,
+		PullRequest: MakePullRequestV2(pr),
+	}
+}
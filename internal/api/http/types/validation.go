@@ -0,0 +1,45 @@
+package types
+
+import (
+	"avito-task/internal/api/validation"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// decodeJSON decodes the request body into dst rejecting unknown fields;
+// unknown fields and values of wrong type are reported as field errors.
+func decodeJSON(r *http.Request, dst any) error {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(dst)
+	if err == nil {
+		if dec.More() {
+			return errors.New("request body must contain a single JSON object")
+		}
+
+		return nil
+	}
+
+	var (
+		v       validation.Validator
+		typeErr *json.UnmarshalTypeError
+	)
+
+	switch {
+	case errors.As(err, &typeErr):
+		v.Add(typeErr.Field, "must be of type %s", typeErr.Type.Kind())
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field, _ := strings.CutPrefix(err.Error(), "json: unknown field ")
+		v.Add(strings.Trim(field, `"`), "unknown field")
+	case errors.Is(err, io.EOF):
+		return errors.New("request body is empty")
+	default:
+		return err
+	}
+
+	return v.Err()
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"avito-task/internal/api/validation"
+	"avito-task/internal/domain"
+	"avito-task/internal/usecases"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pkgMiddleware "avito-task/pkg/http/middleware"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAddTeamRequestValidation(t *testing.T) {
+	fieldsOf := func(body string) []string {
+		req := httptest.NewRequest(http.MethodPost, "/team/add", strings.NewReader(body))
+
+		_, err := CreateAddTeamRequest(req)
+		if err == nil {
+			return nil
+		}
+
+		var verr *pkgMiddleware.ValidationError
+		require.True(t, errors.As(err, &verr), err.Error())
+
+		fields := make([]string, 0, len(verr.Fields))
+		for _, f := range verr.Fields {
+			fields = append(fields, f.Field)
+		}
+
+		return fields
+	}
+
+	require.Empty(t, fieldsOf(`{"team_name":"backend","members":[{"user_id":"u1","username":"Alice","is_active":true}]}`))
+
+	require.Equal(t, []string{"team_name", "members.1.user_id", "members.1.username"}, fieldsOf(
+		`{"team_name":"`+strings.Repeat("a", validation.MaxIDLength+1)+`","members":[`+
+			`{"user_id":"u1","username":"Alice"},{"user_id":"u 2","username":"\u0007"}]}`,
+	))
+
+	require.Equal(t, []string{"members.1.user_id"}, fieldsOf(
+		`{"team_name":"backend","members":[{"user_id":"u1","username":"A"},{"user_id":"u1","username":"B"}]}`,
+	))
+
+	require.Equal(t, []string{"extra"}, fieldsOf(`{"team_name":"backend","members":[],"extra":1}`))
+	require.Equal(t, []string{"members.0.is_active"}, fieldsOf(
+		`{"team_name":"backend","members":[{"user_id":"u1","username":"A","is_active":"yes"}]}`,
+	))
+}
+
+func TestCreateBatchRequest(t *testing.T) {
+	newReq := func(body string) *http.Request {
+		return httptest.NewRequest(http.MethodPost, "/batch", strings.NewReader(body))
+	}
+
+	req, err := CreateBatchRequest(newReq(`{"atomic":true,"operations":[`+
+		`{"op":"set_is_active","user_id":"u2","is_active":false},`+
+		`{"op":"reassign","pull_request_id":"pr-1","old_reviewer_id":"u3","if_version":2}]}`), 10)
+	require.NoError(t, err)
+	require.True(t, req.Atomic)
+	require.Equal(t, "u2", req.Operations[0].UserID)
+	require.Equal(t, "u3", req.Operations[1].UserID)
+	require.Equal(t, int64(2), req.Operations[1].IfVersion)
+
+	_, err = CreateBatchRequest(newReq(`{"operations":[{"op":"merge_pr"},{"op":"set_is_active","user_id":"u2"}]}`), 1)
+
+	var verr *pkgMiddleware.ValidationError
+	require.True(t, errors.As(err, &verr))
+	require.Equal(t, []pkgMiddleware.FieldError{
+		{Field: "operations", Message: "must contain at most 1 operations"},
+		{Field: "operations.0.pull_request_id", Message: "is required"},
+		{Field: "operations.1.is_active", Message: "is required"},
+	}, verr.Fields)
+}
+
+func TestReadImportRecords(t *testing.T) {
+	records, err := ReadImportRecords(strings.NewReader(
+		"pull_request_id,pull_request_name,author_id,status,reviewers,created_at\n"+
+			"pr-1,Add search,u1,merged,u2; u3,2025-10-01T09:00:00+03:00\n"+
+			"pr-2,\"Quoted, name\",u1,,,\n"+
+			"pr 3,Bad,u1,CLOSED,,yesterday\n",
+	), ImportFormatCSV, 10)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	require.Empty(t, records[0].Problems)
+	require.Equal(t, domain.PRMerged, records[0].PR.Status)
+	require.Equal(t, []string{"u2", "u3"}, records[0].PR.Reviewers)
+	require.Equal(t, "2025-10-01T06:00:00Z", records[0].PR.CreatedAt.Format(time.RFC3339))
+
+	require.Equal(t, 3, records[1].Line)
+	require.Empty(t, records[1].Problems)
+	require.True(t, records[1].HasReviewers)
+	require.Empty(t, records[1].PR.Reviewers)
+
+	require.Equal(t, "Quoted, name", records[1].PR.Name)
+
+	require.Equal(t, 4, records[2].Line)
+	require.Equal(t, []usecases.ImportProblem{
+		{Field: "pull_request_id", Message: "may contain only latin letters, digits, '.', '_' and '-'"},
+		{Field: "status", Message: "must be OPEN or MERGED"},
+		{Field: "created_at", Message: "must be RFC 3339 timestamp, e.g. 2025-10-24T12:34:56Z"},
+	}, records[2].Problems)
+
+	_, err = ReadImportRecords(strings.NewReader("pull_request_id,author_id\n"), ImportFormatCSV, 10)
+	require.ErrorContains(t, err, `required CSV column "pull_request_name" is missing`)
+
+	records, err = ReadImportRecords(strings.NewReader(
+		`{"pull_request_id":"pr-1","pull_request_name":"A","author_id":"u1"}`+"\n\n"+
+			`{"pull_request_id":"pr-2","pull_request_name":"B","author_id":"u1","reviewers":[],"extra":1}`+"\n",
+	), ImportFormatNDJSON, 10)
+	require.NoError(t, err)
+	require.False(t, records[0].HasReviewers)
+	require.Equal(t, 3, records[1].Line)
+	require.Equal(t, "record", records[1].Problems[0].Field)
+
+	_, err = ReadImportRecords(strings.NewReader("{}\n{}\n"), ImportFormatNDJSON, 1)
+	require.ErrorIs(t, err, ErrTooManyRecords)
+}
@@ -28,21 +28,21 @@ func NewUserHandler(
 
 func (h *UserHandler) WithUserHandlers() handlers.RouterOption {
 	return func (r chi.Router) {
-		r.Post(h.pathCfg.SetIsActiveUser, h.setIsActiveHandler)
-		r.Get(h.pathCfg.GetReviewUser, h.getReviewHandler)
+		r.With(requireRoles(managerRoles...)).Post(h.pathCfg.SetIsActiveUser, h.setIsActiveHandler)
+		r.With(requireRoles(anyRole...)).Get(h.pathCfg.GetReviewUser, h.getReviewHandler)
 	}
 }
 
 func (h *UserHandler) setIsActiveHandler(w http.ResponseWriter, r *http.Request) {
 	req, err := types.CreateSetIsActiveRequest(r)
 	if err != nil {
-		response.ProcessCreatingRequestError(w, err)
+		response.ProcessCreatingRequestError(w, r, err)
 		return
 	}
 
 	res, err := h.userSvc.SetIsActive(r.Context(), req.UserID, req.IsActive)
 	if err != nil {
-		response.ProcessError(w, err)
+		response.ProcessError(w, r, err)
 		return
 	}
 
@@ -52,13 +52,13 @@ func (h *UserHandler) setIsActiveHandler(w http.ResponseWriter, r *http.Request)
 func (h *UserHandler) getReviewHandler(w http.ResponseWriter, r *http.Request) {
 	req, err := types.CreateGetReviewRequest(r)
 	if err != nil {
-		response.ProcessCreatingRequestError(w, err)
+		response.ProcessCreatingRequestError(w, r, err)
 		return
 	}
 
 	res, err := h.userSvc.GetReview(r.Context(), req.UserID)
 	if err != nil {
-		response.ProcessError(w, err)
+		response.ProcessError(w, r, err)
 		return
 	}
 
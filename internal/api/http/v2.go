@@ -0,0 +1,271 @@
+package http
+
+import (
+	"avito-task/internal/api/http/response"
+	"avito-task/internal/api/http/types"
+	"avito-task/internal/config"
+	"avito-task/internal/domain"
+	"avito-task/internal/repository"
+	"avito-task/internal/usecases"
+	"avito-task/pkg/http/handlers"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+
+	pkgMiddleware "avito-task/pkg/http/middleware"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// V2Handler serves resource-oriented /v2 API on top of the same usecases as v1.
+type V2Handler struct {
+	teamSvc   usecases.TeamService
+	userSvc   usecases.UserService
+	prSvc     usecases.PullRequestService
+	pathCfg   config.PathConfig
+	validator *pkgMiddleware.OpenAPIValidator
+}
+
+// NewV2Handler creates the handler, requests are checked against v2 OpenAPI document
+// by the validator unless it is nil.
+func NewV2Handler(
+	teamSvc usecases.TeamService,
+	userSvc usecases.UserService,
+	prSvc usecases.PullRequestService,
+	pathCfg config.PathConfig,
+	validator *pkgMiddleware.OpenAPIValidator,
+) *V2Handler {
+	return &V2Handler{
+		teamSvc:   teamSvc,
+		userSvc:   userSvc,
+		prSvc:     prSvc,
+		pathCfg:   pathCfg,
+		validator: validator,
+	}
+}
+
+func (h *V2Handler) WithV2Handlers() handlers.RouterOption {
+	return func(r chi.Router) {
+		r.Route(h.pathCfg.V2, func(r chi.Router) {
+			if h.validator != nil {
+				r.Use(h.validator.Middleware(response.ProcessCreatingRequestError))
+			}
+
+			r.With(requireRoles(domain.AccessAdmin)).Post("/teams", h.createTeamHandler)
+			r.With(requireRoles(anyRole...)).Get("/teams/{name}", h.getTeamHandler)
+			r.With(requireRoles(anyRole...)).Get("/teams/{name}/stats", h.getTeamStatsHandler)
+			r.With(requireRoles(managerRoles...)).Post("/teams/{name}/deactivate", h.deactivateTeamHandler)
+
+			r.With(requireRoles(anyRole...)).Get("/users/{id}", h.getUserHandler)
+			r.With(requireRoles(managerRoles...)).Patch("/users/{id}", h.updateUserHandler)
+			r.With(requireRoles(anyRole...)).Get("/users/{id}/reviews", h.getUserReviewsHandler)
+
+			r.With(requireRoles(anyRole...)).Post("/pull-requests", h.createPRHandler)
+			r.With(requireRoles(anyRole...)).Get("/pull-requests/{id}", h.getPRHandler)
+			r.With(requireRoles(anyRole...)).Post("/pull-requests/{id}/merge", h.mergePRHandler)
+			r.With(requireRoles(domain.AccessAdmin, domain.AccessTeamLead, domain.AccessMember)).
+				Post("/pull-requests/{id}/reassign", h.reassignPRHandler)
+		})
+	}
+}
+
+// location returns path of the created resource relative to the API root.
+func (h *V2Handler) location(collection, id string) string {
+	return path.Join(h.pathCfg.APIPath, h.pathCfg.V2, collection, url.PathEscape(id))
+}
+
+// Teams -----------------------------------------------------
+
+func (h *V2Handler) createTeamHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateAddTeamV2Request(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	res, err := h.teamSvc.CreateTeam(r.Context(), req.Team)
+	if err != nil {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", h.location("teams", res.Name))
+	response.WriteResponse(w, http.StatusCreated, types.MakeTeamV2(res))
+}
+
+func (h *V2Handler) getTeamHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateTeamV2Request(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	res, err := h.teamSvc.GetTeam(r.Context(), req.Name)
+	if err != nil {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	response.WriteResponse(w, http.StatusOK, types.MakeTeamV2(res))
+}
+
+func (h *V2Handler) getTeamStatsHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateTeamV2Request(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	res, err := h.teamSvc.GetTeamStats(r.Context(), req.Name)
+	if err != nil {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	response.WriteResponse(w, http.StatusOK, types.CreateTeamStatsV2Response(res))
+}
+
+func (h *V2Handler) deactivateTeamHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateTeamV2Request(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	res, err := h.teamSvc.DeactivateTeam(r.Context(), req.Name)
+	if err != nil {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	response.WriteResponse(w, http.StatusOK, types.CreateDeactivateTeamV2Response(req.Name, res))
+}
+
+// Users -----------------------------------------------------
+
+func (h *V2Handler) getUserHandler(w http.ResponseWriter, r *http.Request) {
+	const op = "V2Handler.getUserHandler"
+
+	req, err := types.CreateUserV2Request(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	users, err := h.userSvc.GetUsers(r.Context(), []string{req.ID})
+	if err != nil {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	if len(users) == 0 {
+		response.ProcessError(w, r, fmt.Errorf("%s: %w", op, repository.ErrUserNotExists))
+		return
+	}
+
+	response.WriteResponse(w, http.StatusOK, types.MakeUserV2(users[0]))
+}
+
+func (h *V2Handler) updateUserHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateUpdateUserV2Request(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	res, err := h.userSvc.SetIsActive(r.Context(), req.ID, req.IsActive)
+	if err != nil {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	response.WriteResponse(w, http.StatusOK, types.MakeUserV2(res))
+}
+
+func (h *V2Handler) getUserReviewsHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateUserV2Request(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	res, err := h.userSvc.GetReview(r.Context(), req.ID)
+	if err != nil {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	response.WriteResponse(w, http.StatusOK, types.CreateUserReviewsV2Response(req.ID, res))
+}
+
+// Pull requests ---------------------------------------------
+
+func (h *V2Handler) createPRHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.MakeCreatePRV2Request(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	res, err := h.prSvc.CreatePullRequest(r.Context(), req.PR)
+	if err != nil {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Location", h.location("pull-requests", res.ID))
+	w.Header().Set("ETag", types.PRETag(res))
+	response.WriteResponse(w, http.StatusCreated, types.MakePullRequestV2(res))
+}
+
+func (h *V2Handler) getPRHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreatePullRequestV2Request(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	res, err := h.prSvc.GetPullRequest(r.Context(), req.ID)
+	if err != nil {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", types.PRETag(res))
+	response.WriteResponse(w, http.StatusOK, types.MakePullRequestV2(res))
+}
+
+func (h *V2Handler) mergePRHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreatePullRequestV2Request(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	res, err := h.prSvc.Merge(r.Context(), req.ID, req.IfVersion)
+	if err != nil {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", types.PRETag(res))
+	response.WriteResponse(w, http.StatusOK, types.MakePullRequestV2(res))
+}
+
+func (h *V2Handler) reassignPRHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := types.CreateReassignV2Request(r)
+	if err != nil {
+		response.ProcessCreatingRequestError(w, r, err)
+		return
+	}
+
+	newRewID, pr, err := h.prSvc.Reassign(r.Context(), req.ID, req.OldReviewerID, req.IfVersion)
+	if err != nil {
+		response.ProcessError(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", types.PRETag(pr))
+	response.WriteResponse(w, http.StatusOK, types.CreateReassignV2Response(newRewID, pr))
+}
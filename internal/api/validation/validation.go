@@ -0,0 +1,88 @@
+// Package validation implements field-level checks of requests shared by HTTP, gRPC and
+// GraphQL APIs, so that every API rejects the same invalid values before they reach storage.
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	pkgMiddleware "avito-task/pkg/http/middleware"
+)
+
+const (
+	// MaxIDLength and MaxNameLength match varchar(100) columns of the schema.
+	MaxIDLength   = 100
+	MaxNameLength = 100
+)
+
+// idPattern lists characters allowed in IDs and team names.
+var idPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// Validator collects field-level errors of a request.
+type Validator struct {
+	fields []pkgMiddleware.FieldError
+}
+
+func (v *Validator) Add(field, format string, args ...any) {
+	v.fields = append(v.fields, pkgMiddleware.FieldError{
+		Field:   field,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// Required reports whether the value is set, adding an error otherwise.
+func (v *Validator) Required(field, val string) bool {
+	if len(val) == 0 {
+		v.Add(field, "is required")
+		return false
+	}
+
+	return true
+}
+
+// ID checks a required identifier (user, PR or team name).
+func (v *Validator) ID(field, val string) {
+	if !v.Required(field, val) {
+		return
+	}
+
+	switch {
+	case utf8.RuneCountInString(val) > MaxIDLength:
+		v.Add(field, "must be at most %d characters long", MaxIDLength)
+	case !idPattern.MatchString(val):
+		v.Add(field, "may contain only latin letters, digits, '.', '_' and '-'")
+	}
+}
+
+// Name checks a required human-readable name.
+func (v *Validator) Name(field, val string) {
+	if !v.Required(field, val) {
+		return
+	}
+
+	switch {
+	case utf8.RuneCountInString(val) > MaxNameLength:
+		v.Add(field, "must be at most %d characters long", MaxNameLength)
+	case !utf8.ValidString(val) || strings.IndexFunc(val, unicode.IsControl) >= 0:
+		v.Add(field, "must not contain control characters")
+	case len(strings.TrimSpace(val)) == 0:
+		v.Add(field, "must not be blank")
+	}
+}
+
+// Fields returns collected errors.
+func (v *Validator) Fields() []pkgMiddleware.FieldError {
+	return v.fields
+}
+
+// Err returns collected errors as *pkgMiddleware.ValidationError, nil if there are none.
+func (v *Validator) Err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+
+	return &pkgMiddleware.ValidationError{Fields: v.fields}
+}
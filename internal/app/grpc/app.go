@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	apigrpc "avito-task/internal/api/grpc"
+	apihttp "avito-task/internal/api/http"
+	"avito-task/internal/config"
+	"avito-task/internal/usecases"
+	"avito-task/pkg/grpc/interceptor"
+	"avito-task/pkg/jwks"
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+
+	pkgConfig "avito-task/pkg/config"
+	pb "avito-task/pkg/pb/reviewer/v1"
+
+	"google.golang.org/grpc"
+)
+
+type App struct {
+	server  *grpc.Server
+	address string
+}
+
+// New creates gRPC server with the same services, authentication and roles as the HTTP one.
+func New(
+	grpcCfg pkgConfig.GRPCConfig,
+	teamSvc usecases.TeamService,
+	userSvc usecases.UserService,
+	prSvc usecases.PullRequestService,
+	authSvc usecases.AuthService,
+	authCfg config.AuthConfig,
+	verifier *jwks.Verifier,
+) *App {
+	// Paths are used only for HTTP routes of the handler, here it just checks credentials.
+	authn := apihttp.NewAuthHandler(authSvc, authCfg, config.PathConfig{}, verifier)
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			interceptor.RequestID(),
+			interceptor.Logger(),
+			interceptor.Recovery(),
+			interceptor.Authenticate(apigrpc.MetadataAuthenticator(authn, authCfg.APIKeyHeader), apigrpc.ProcessError),
+			interceptor.RequireRoles(apigrpc.MethodRoles(), apigrpc.ProcessError),
+			apigrpc.ActorInterceptor(),
+		),
+	)
+
+	pb.RegisterTeamServiceServer(server, apigrpc.NewTeamServer(teamSvc))
+	pb.RegisterUserServiceServer(server, apigrpc.NewUserServer(userSvc))
+	pb.RegisterPullRequestServiceServer(server, apigrpc.NewPullRequestServer(prSvc))
+
+	return &App{
+		server:  server,
+		address: grpcCfg.Address,
+	}
+}
+
+func (a *App) Run() error {
+	const op = "grpc.App.Run"
+
+	lis, err := net.Listen("tcp", a.address)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	slog.Info("starting grpc server", slog.String("op", op), slog.String("address", a.address))
+	return a.Serve(lis)
+}
+
+// Serve accepts connections on the given listener until Stop.
+func (a *App) Serve(lis net.Listener) error {
+	const op = "grpc.App.Serve"
+
+	if err := a.server.Serve(lis); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Stop waits for running calls to finish and closes listeners; when ctx is done
+// before that, remaining calls are cancelled.
+func (a *App) Stop(ctx context.Context) {
+	const op = "grpc.App.Stop"
+
+	slog.Info("grpc server shutting down", slog.String("op", op))
+
+	stopped := make(chan struct{})
+	go func() {
+		a.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		slog.Warn("grpc graceful stop timed out, cancelling running calls", slog.String("op", op))
+		a.server.Stop()
+	}
+}
@@ -0,0 +1,148 @@
+package grpc_test
+
+import (
+	"avito-task/internal/api/validation"
+	"avito-task/internal/config"
+	"avito-task/internal/repository/memory"
+	"avito-task/internal/usecases/service"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	grpcapp "avito-task/internal/app/grpc"
+	pkgConfig "avito-task/pkg/config"
+	pb "avito-task/pkg/pb/reviewer/v1"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const adminKey = "avt_test_admin_key"
+
+// startApp serves gRPC API over in-memory storage and connection.
+func startApp(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	store := memory.NewStore()
+	teamRepo := memory.NewTeamRepo(store)
+	userRepo := memory.NewUserRepo(store)
+	prRepo := memory.NewPullRequestRepo(store)
+	auditRepo := memory.NewAuditRepo(store)
+
+	authSvc := service.NewAuthService(store, memory.NewAPIKeyRepo(store), auditRepo)
+	require.NoError(t, authSvc.BootstrapKey(context.Background(), adminKey))
+
+	app := grpcapp.New(
+		pkgConfig.GRPCConfig{},
+		service.NewTeamService(store, teamRepo, userRepo, prRepo, auditRepo),
+		service.NewUserService(store, userRepo, prRepo, auditRepo),
+		service.NewPullRequestService(store, prRepo, userRepo, teamRepo, auditRepo, service.NewSeededReviewerPicker(7)),
+		authSvc,
+		config.AuthConfig{Enabled: true, APIKeyHeader: "X-API-Key"},
+		nil,
+	)
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = app.Serve(lis) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		app.Stop(ctx)
+	})
+
+	return conn
+}
+
+func requireStatus(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, code, st.Code())
+
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	require.Equal(t, reason, info.GetReason())
+}
+
+func TestPullRequestCalls(t *testing.T) {
+	conn := startApp(t)
+	teams := pb.NewTeamServiceClient(conn)
+	prs := pb.NewPullRequestServiceClient(conn)
+
+	_, err := teams.GetTeam(context.Background(), &pb.GetTeamRequest{TeamName: "backend"})
+	requireStatus(t, err, codes.Unauthenticated, "UNAUTHORIZED")
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", adminKey)
+
+	_, err = teams.CreateTeam(ctx, &pb.CreateTeamRequest{Team: &pb.Team{
+		TeamName: "backend",
+		Members: []*pb.User{
+			{UserId: "u1", Username: "u1", IsActive: true},
+			{UserId: "u2", Username: "u2", IsActive: true, Role: pb.UserRole_USER_ROLE_SENIOR},
+			{UserId: "u3", Username: "u3", IsActive: true},
+		},
+	}})
+	require.NoError(t, err)
+
+	_, err = teams.CreateTeam(ctx, &pb.CreateTeamRequest{Team: &pb.Team{TeamName: "backend"}})
+	requireStatus(t, err, codes.AlreadyExists, "TEAM_EXISTS")
+
+	_, err = teams.GetTeam(ctx, &pb.GetTeamRequest{TeamName: "frontend"})
+	requireStatus(t, err, codes.NotFound, "NOT_FOUND")
+
+	_, err = teams.GetTeam(ctx, &pb.GetTeamRequest{})
+	requireStatus(t, err, codes.InvalidArgument, "BAD_REQUEST")
+
+	_, err = prs.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{
+		PullRequestId:   strings.Repeat("a", validation.MaxIDLength+1),
+		PullRequestName: "feature",
+		AuthorId:        "u1",
+	})
+	requireStatus(t, err, codes.InvalidArgument, "BAD_REQUEST")
+	require.ErrorContains(t, err, "pull_request_id: must be at most 100 characters long")
+
+	created, err := prs.CreatePullRequest(ctx, &pb.CreatePullRequestRequest{
+		PullRequestId:   "pr-1",
+		PullRequestName: "feature",
+		AuthorId:        "u1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, pb.PullRequestStatus_PULL_REQUEST_STATUS_OPEN, created.GetPr().GetStatus())
+	require.Len(t, created.GetPr().GetAssignedReviewers(), 2)
+	require.NotNil(t, created.GetPr().GetCreatedAt())
+
+	_, err = prs.Merge(ctx, &pb.MergeRequest{PullRequestId: "pr-1", IfVersion: created.GetPr().GetVersion() + 1})
+	requireStatus(t, err, codes.Aborted, "PRECONDITION_FAILED")
+
+	merged, err := prs.Merge(ctx, &pb.MergeRequest{PullRequestId: "pr-1", IfVersion: created.GetPr().GetVersion()})
+	require.NoError(t, err)
+	require.Equal(t, pb.PullRequestStatus_PULL_REQUEST_STATUS_MERGED, merged.GetPr().GetStatus())
+	require.NotNil(t, merged.GetPr().GetMergedAt())
+
+	_, err = prs.Reassign(ctx, &pb.ReassignRequest{
+		PullRequestId: "pr-1",
+		OldReviewerId: created.GetPr().GetAssignedReviewers()[0],
+	})
+	requireStatus(t, err, codes.FailedPrecondition, "PR_MERGED")
+}
@@ -1,17 +1,23 @@
 package http
 
 import (
+	"avito-task/internal/api/graphql"
 	apihttp "avito-task/internal/api/http"
+	"avito-task/internal/api/http/response"
 	"avito-task/internal/config"
 	"avito-task/internal/usecases"
+	"avito-task/pkg/health"
 	"avito-task/pkg/http/handlers"
+	"avito-task/pkg/jwks"
 	"context"
-	"log"
+	"log/slog"
 	"net/http"
 
 	pkgConfig "avito-task/pkg/config"
+	pkgMiddleware "avito-task/pkg/http/middleware"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type App struct {
@@ -25,20 +31,56 @@ func New(
 	teamSvc usecases.TeamService,
 	userSvc usecases.UserService,
 	prSvc usecases.PullRequestService,
+	authSvc usecases.AuthService,
+	auditSvc usecases.AuditService,
+	idemSvc usecases.IdempotencyService,
+	batchSvc usecases.BatchService,
+	importSvc usecases.ImportService,
+	exportSvc usecases.ExportService,
+	graphQLSchema *graphql.Schema,
+	validator *pkgMiddleware.OpenAPIValidator,
+	validatorV2 *pkgMiddleware.OpenAPIValidator,
+	authCfg config.AuthConfig,
+	verifier *jwks.Verifier,
+	registry *prometheus.Registry,
+	checker *health.Checker,
 ) *App {
 	teamHandler := apihttp.NewTeamHandler(teamSvc, pathCfg)
 	userHandler := apihttp.NewUserHandler(userSvc, pathCfg)
 	prHandler := apihttp.NewPullRequestHandler(prSvc, pathCfg)
+	authHandler := apihttp.NewAuthHandler(authSvc, authCfg, pathCfg, verifier)
+	auditHandler := apihttp.NewAuditHandler(auditSvc, pathCfg)
+	idemHandler := apihttp.NewIdempotencyHandler(idemSvc)
+	graphQLHandler := apihttp.NewGraphQLHandler(graphQLSchema, pathCfg)
+	batchHandler := apihttp.NewBatchHandler(batchSvc, pathCfg, svcCfg.BatchMaxOperations)
+	importHandler := apihttp.NewImportHandler(importSvc, pathCfg, svcCfg.ImportMaxRecords)
+	exportHandler := apihttp.NewExportHandler(exportSvc, pathCfg, svcCfg.ExportTimeout)
+	v2Handler := apihttp.NewV2Handler(teamSvc, userSvc, prSvc, pathCfg, validatorV2)
 
 	router := chi.NewRouter()
 	handlers.RouteHandlers(router, pathCfg.APIPath,
+		handlers.WithTracing(),
+		handlers.WithRequestID(),
+		handlers.WithMetrics(pkgMiddleware.NewHTTPMetrics(registry)),
 		handlers.WithLogger(),
 		handlers.WithRecovery(),
+		handlers.WithAuth(authHandler, response.ProcessError),
+		handlers.WithOpenAPIValidation(validator, response.ProcessCreatingRequestError),
 		handlers.WithSwagger(pathCfg.Swagger, svcCfg.SwaggerFsRoot),
-		handlers.WithHealthHandler(),
-		teamHandler.WithTeamHandlers(),
-		userHandler.WithUserHandlers(),
-		prHandler.WithPRHandlers(),
+		handlers.WithHealthHandler(checker),
+		handlers.WithMetricsHandler(pathCfg.Metrics, registry),
+		handlers.WithIdempotency(idemHandler, response.ProcessError,
+			teamHandler.WithTeamHandlers(),
+			userHandler.WithUserHandlers(),
+			prHandler.WithPRHandlers(),
+			batchHandler.WithBatchHandlers(),
+			v2Handler.WithV2Handlers(),
+		),
+		importHandler.WithImportHandlers(),
+		exportHandler.WithExportHandlers(),
+		authHandler.WithAuthHandlers(),
+		auditHandler.WithAuditHandlers(),
+		graphQLHandler.WithGraphQLHandlers(),
 	)
 
 	srv := &http.Server{
@@ -57,13 +99,13 @@ func New(
 func (a *App) Run() error {
 	const op = "http.App.Run"
 
-	log.Printf("[INFO] %s: starting server at %s...", op, a.server.Addr)
+	slog.Info("starting server", slog.String("op", op), slog.String("address", a.server.Addr))
 	return a.server.ListenAndServe()
 }
 
 func (a *App) Stop(ctx context.Context) error {
 	const op = "http.App.Stop"
 
-	log.Printf("[INFO] %s: http server shutting down", op)
+	slog.Info("http server shutting down", slog.String("op", op))
 	return a.server.Shutdown(ctx)
 }
@@ -0,0 +1,396 @@
+// Package backup implements the archive of the full service state: a gzip-compressed
+// NDJSON file with the header, rows of domain.BackupEntities and the trailer counting them.
+// The format does not depend on the database schema, so archives can be moved between
+// environments; its version is increased on incompatible changes only.
+package backup
+
+import (
+	"avito-task/internal/domain"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"time"
+)
+
+const (
+	// FormatName identifies archives in their header.
+	FormatName = "avito-task-backup"
+	// Version is the version of the archive format written by Writer and the latest one read by Reader.
+	Version = 1
+
+	// trailerEntity marks the last line of the archive.
+	trailerEntity = "end"
+	maxLineSize   = 16 * 1024 * 1024
+)
+
+var ErrInvalidArchive = errors.New("invalid backup archive")
+
+type header struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type trailer struct {
+	Entity string                      `json:"entity"`
+	Counts map[domain.ExportEntity]int `json:"counts"`
+}
+
+// Rows ------------------------------------------------------
+
+type teamRow struct {
+	Entity        domain.ExportEntity `json:"entity"`
+	TeamName      string              `json:"team_name"`
+	RequiredRoles []domain.UserRole   `json:"required_reviewer_roles"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+type userRow struct {
+	Entity    domain.ExportEntity `json:"entity"`
+	UserID    string              `json:"user_id"`
+	Username  string              `json:"username"`
+	TeamName  string              `json:"team_name"`
+	IsActive  bool                `json:"is_active"`
+	Role      domain.UserRole     `json:"role"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+type pullRequestRow struct {
+	Entity          domain.ExportEntity `json:"entity"`
+	PullRequestID   string              `json:"pull_request_id"`
+	PullRequestName string              `json:"pull_request_name"`
+	AuthorID        string              `json:"author_id"`
+	Status          domain.PRStatus     `json:"status"`
+	CreatedAt       *time.Time          `json:"created_at"`
+	MergedAt        *time.Time          `json:"merged_at"`
+	Version         int64               `json:"version"`
+	UpdatedAt       time.Time           `json:"updated_at"`
+}
+
+type reviewRow struct {
+	Entity        domain.ExportEntity `json:"entity"`
+	PullRequestID string              `json:"pull_request_id"`
+	UserID        string              `json:"user_id"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+type auditRow struct {
+	Entity     domain.ExportEntity `json:"entity"`
+	ID         int64               `json:"id"`
+	ActorID    string              `json:"actor_id"`
+	ActorRole  string              `json:"actor_role"`
+	Action     domain.AuditAction  `json:"action"`
+	TargetType domain.AuditTarget  `json:"target_type"`
+	TargetID   string              `json:"target_id"`
+	Before     json.RawMessage     `json:"before,omitempty"`
+	After      json.RawMessage     `json:"after,omitempty"`
+	RequestID  string              `json:"request_id,omitempty"`
+	CreatedAt  time.Time           `json:"created_at"`
+}
+
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	u := t.UTC()
+
+	return &u
+}
+
+func toRow(rec *domain.ExportRecord) (any, error) {
+	updatedAt := rec.UpdatedAt.UTC()
+
+	switch rec.Entity {
+	case domain.ExportTeams:
+		roles := rec.Team.RequiredRoles
+		if roles == nil {
+			roles = []domain.UserRole{}
+		}
+
+		return &teamRow{rec.Entity, rec.Team.Name, roles, updatedAt}, nil
+	case domain.ExportUsers:
+		u := rec.User
+
+		return &userRow{rec.Entity, u.ID, u.Name, u.TeamName, u.IsActive, u.Role, updatedAt}, nil
+	case domain.ExportPullRequests:
+		pr := rec.PR
+
+		return &pullRequestRow{
+			rec.Entity, pr.ID, pr.Name, pr.AuthorID, pr.Status,
+			utc(pr.CreatedAt), utc(pr.MergedAt), pr.Version, updatedAt,
+		}, nil
+	case domain.ExportReviews:
+		return &reviewRow{rec.Entity, rec.Review.PullRequestID, rec.Review.UserID, updatedAt}, nil
+	case domain.ExportAudit:
+		e := rec.Audit
+
+		return &auditRow{
+			rec.Entity, e.ID, e.ActorID, e.ActorRole, e.Action, e.TargetType, e.TargetID,
+			e.Before, e.After, e.RequestID, updatedAt,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown entity %q", rec.Entity)
+}
+
+// fromRow decodes the line of given entity, unknown fields are rejected.
+func fromRow(entity domain.ExportEntity, line []byte) (*domain.ExportRecord, error) {
+	decode := func(dst any) error {
+		dec := json.NewDecoder(bytes.NewReader(line))
+		dec.DisallowUnknownFields()
+
+		return dec.Decode(dst)
+	}
+
+	rec := domain.ExportRecord{Entity: entity}
+
+	switch entity {
+	case domain.ExportTeams:
+		var row teamRow
+		if err := decode(&row); err != nil {
+			return nil, err
+		}
+
+		rec.Team = &domain.Team{Name: row.TeamName, RequiredRoles: row.RequiredRoles}
+		rec.UpdatedAt = row.UpdatedAt
+	case domain.ExportUsers:
+		var row userRow
+		if err := decode(&row); err != nil {
+			return nil, err
+		}
+
+		rec.User = &domain.User{
+			ID:       row.UserID,
+			Name:     row.Username,
+			TeamName: row.TeamName,
+			IsActive: row.IsActive,
+			Role:     row.Role,
+		}
+		rec.UpdatedAt = row.UpdatedAt
+	case domain.ExportPullRequests:
+		var row pullRequestRow
+		if err := decode(&row); err != nil {
+			return nil, err
+		}
+
+		rec.PR = &domain.PullRequest{
+			ID:        row.PullRequestID,
+			Name:      row.PullRequestName,
+			AuthorID:  row.AuthorID,
+			Status:    row.Status,
+			CreatedAt: row.CreatedAt,
+			MergedAt:  row.MergedAt,
+			Version:   row.Version,
+		}
+		rec.UpdatedAt = row.UpdatedAt
+	case domain.ExportReviews:
+		var row reviewRow
+		if err := decode(&row); err != nil {
+			return nil, err
+		}
+
+		rec.Review = &domain.Review{PullRequestID: row.PullRequestID, UserID: row.UserID}
+		rec.UpdatedAt = row.UpdatedAt
+	case domain.ExportAudit:
+		var row auditRow
+		if err := decode(&row); err != nil {
+			return nil, err
+		}
+
+		rec.Audit = &domain.AuditEntry{
+			ID:         row.ID,
+			ActorID:    row.ActorID,
+			ActorRole:  row.ActorRole,
+			Action:     row.Action,
+			TargetType: row.TargetType,
+			TargetID:   row.TargetID,
+			Before:     row.Before,
+			After:      row.After,
+			RequestID:  row.RequestID,
+			CreatedAt:  &row.CreatedAt,
+		}
+		rec.UpdatedAt = row.CreatedAt
+	default:
+		return nil, fmt.Errorf("unknown entity %q", entity)
+	}
+
+	return &rec, nil
+}
+
+// Writer ----------------------------------------------------
+
+// Writer writes the archive, it is complete only after Close.
+type Writer struct {
+	gz     *gzip.Writer
+	enc    *json.Encoder
+	counts map[domain.ExportEntity]int
+}
+
+// NewWriter writes the header of the archive to w.
+func NewWriter(w io.Writer, createdAt time.Time) (*Writer, error) {
+	gz := gzip.NewWriter(w)
+
+	bw := Writer{
+		gz:     gz,
+		enc:    json.NewEncoder(gz),
+		counts: make(map[domain.ExportEntity]int, len(domain.BackupEntities)),
+	}
+
+	if err := bw.enc.Encode(header{Format: FormatName, Version: Version, CreatedAt: createdAt.UTC()}); err != nil {
+		return nil, err
+	}
+
+	return &bw, nil
+}
+
+func (w *Writer) Write(rec *domain.ExportRecord) error {
+	row, err := toRow(rec)
+	if err != nil {
+		return err
+	}
+
+	if err = w.enc.Encode(row); err != nil {
+		return err
+	}
+
+	w.counts[rec.Entity]++
+
+	return nil
+}
+
+// Counts returns the number of written records by entity.
+func (w *Writer) Counts() map[domain.ExportEntity]int {
+	return maps.Clone(w.counts)
+}
+
+// Close writes the trailer and flushes the archive, the underlying writer is not closed.
+func (w *Writer) Close() error {
+	if err := w.enc.Encode(trailer{Entity: trailerEntity, Counts: w.counts}); err != nil {
+		return err
+	}
+
+	return w.gz.Close()
+}
+
+// Reader ----------------------------------------------------
+
+// Reader reads records of the archive checking its header and trailer.
+type Reader struct {
+	sc        *bufio.Scanner
+	line      int
+	counts    map[domain.ExportEntity]int
+	CreatedAt time.Time
+}
+
+// NewReader checks the header of the archive, archives of later versions are rejected.
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+
+	br := Reader{
+		sc:     bufio.NewScanner(gz),
+		counts: make(map[domain.ExportEntity]int, len(domain.BackupEntities)),
+	}
+	br.sc.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	line, err := br.scan()
+	if err != nil {
+		return nil, err
+	}
+
+	var h header
+	if err = json.Unmarshal(line, &h); err != nil || h.Format != FormatName {
+		return nil, fmt.Errorf("%w: missing header, not an archive of this service", ErrInvalidArchive)
+	}
+
+	if h.Version < 1 || h.Version > Version {
+		return nil, fmt.Errorf("%w: unsupported version %d, expected at most %d", ErrInvalidArchive, h.Version, Version)
+	}
+
+	br.CreatedAt = h.CreatedAt
+
+	return &br, nil
+}
+
+// scan returns the next line, io.ErrUnexpectedEOF if there is none.
+func (r *Reader) scan() ([]byte, error) {
+	if !r.sc.Scan() {
+		err := r.sc.Err()
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidArchive, r.line+1, err)
+	}
+
+	r.line++
+
+	return r.sc.Bytes(), nil
+}
+
+// Next returns the next record, io.EOF after the trailer if counts of records match it.
+// The archive without the trailer is truncated and is reported as invalid.
+func (r *Reader) Next() (*domain.ExportRecord, error) {
+	line, err := r.scan()
+	if err != nil {
+		return nil, err
+	}
+
+	var probe struct {
+		Entity domain.ExportEntity `json:"entity"`
+	}
+
+	if err = json.Unmarshal(line, &probe); err != nil {
+		return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidArchive, r.line, err)
+	}
+
+	if probe.Entity == trailerEntity {
+		return nil, r.checkTrailer(line)
+	}
+
+	rec, err := fromRow(probe.Entity, line)
+	if err != nil {
+		return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidArchive, r.line, err)
+	}
+
+	r.counts[rec.Entity]++
+
+	return rec, nil
+}
+
+func (r *Reader) checkTrailer(line []byte) error {
+	var t trailer
+	if err := json.Unmarshal(line, &t); err != nil {
+		return fmt.Errorf("%w: line %d: %w", ErrInvalidArchive, r.line, err)
+	}
+
+	for _, entity := range domain.BackupEntities {
+		if t.Counts[entity] != r.counts[entity] {
+			return fmt.Errorf("%w: %d %s are read, trailer counts %d",
+				ErrInvalidArchive, r.counts[entity], entity, t.Counts[entity])
+		}
+	}
+
+	if r.sc.Scan() {
+		return fmt.Errorf("%w: line %d: data after the trailer", ErrInvalidArchive, r.line+1)
+	}
+
+	if err := r.sc.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+
+	return io.EOF
+}
+
+// Counts returns the number of read records by entity.
+func (r *Reader) Counts() map[domain.ExportEntity]int {
+	return maps.Clone(r.counts)
+}
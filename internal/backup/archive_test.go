@@ -0,0 +1,110 @@
+package backup
+
+import (
+	"avito-task/internal/domain"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func gzipLines(t *testing.T, lines ...string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(strings.Join(lines, "\n") + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	return buf.Bytes()
+}
+
+func readAll(data []byte) ([]*domain.ExportRecord, error) {
+	r, err := NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*domain.ExportRecord
+
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			return records, nil
+		}
+
+		if err != nil {
+			return records, err
+		}
+
+		records = append(records, rec)
+	}
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	createdAt := time.Date(2025, 10, 1, 9, 0, 0, 0, time.UTC)
+	mergedAt := createdAt.Add(time.Hour)
+
+	records := []*domain.ExportRecord{
+		{Entity: domain.ExportTeams, Team: &domain.Team{Name: "backend", RequiredRoles: []domain.UserRole{domain.RoleSenior}}},
+		{Entity: domain.ExportUsers, User: &domain.User{ID: "u1", Name: "Alice", TeamName: "backend", Role: domain.RoleSenior}},
+		{Entity: domain.ExportPullRequests, PR: &domain.PullRequest{ID: "pr-1", Name: "feature", AuthorID: "u1",
+			Status: domain.PRMerged, CreatedAt: &createdAt, MergedAt: &mergedAt, Version: 3}},
+		{Entity: domain.ExportAudit, Audit: &domain.AuditEntry{ID: 7, ActorID: "system", Action: domain.AuditPRMerge,
+			After: []byte(`{"status":"MERGED"}`), CreatedAt: &mergedAt}},
+	}
+
+	for _, rec := range records {
+		rec.UpdatedAt = mergedAt
+	}
+
+	records[3].UpdatedAt = *records[3].Audit.CreatedAt
+
+	var buf bytes.Buffer
+
+	w, err := NewWriter(&buf, time.Now())
+	require.NoError(t, err)
+
+	for _, rec := range records {
+		require.NoError(t, w.Write(rec))
+	}
+
+	require.NoError(t, w.Close())
+
+	got, err := readAll(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, records, got)
+}
+
+func TestArchiveReaderErrors(t *testing.T) {
+	const (
+		head = `{"format":"avito-task-backup","version":1,"created_at":"2025-10-01T09:00:00Z"}`
+		team = `{"entity":"teams","team_name":"backend","required_reviewer_roles":[],"updated_at":"2025-10-01T09:00:00Z"}`
+	)
+
+	_, err := readAll([]byte("not gzip"))
+	require.ErrorIs(t, err, ErrInvalidArchive)
+
+	_, err = readAll(gzipLines(t, `{"format":"avito-task-backup","version":2}`))
+	require.ErrorContains(t, err, "unsupported version 2")
+
+	records, err := readAll(gzipLines(t, head, team))
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.Len(t, records, 1)
+
+	_, err = readAll(gzipLines(t, head, team, `{"entity":"end","counts":{"teams":2}}`))
+	require.ErrorContains(t, err, "1 teams are read, trailer counts 2")
+
+	_, err = readAll(gzipLines(t, head, strings.Replace(team, `"updated_at"`, `"extra":1,"updated_at"`, 1)))
+	require.ErrorContains(t, err, `line 2: json: unknown field "extra"`)
+
+	records, err = readAll(gzipLines(t, head, team, `{"entity":"end","counts":{"teams":1}}`))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+}
@@ -1,8 +1,13 @@
 package config
 
 import (
+	"avito-task/internal/repository"
 	pkgConfig "avito-task/pkg/config"
 	"avito-task/pkg/database/postgres"
+	"avito-task/pkg/jwks"
+	"avito-task/pkg/logger"
+	"avito-task/pkg/tracing"
+	"time"
 )
 
 type PathConfig struct {
@@ -19,17 +24,90 @@ type PathConfig struct {
 	CreatePR   string `yaml:"create_pr" env-required:"true"`
 	MergePR    string `yaml:"merge_pr" env-required:"true"`
 	ReassignPR string `yaml:"reassign_pr" env-required:"true"`
+	ImportPR   string `yaml:"import_pr" env-required:"true"`
+
+	IssueAPIKey  string `yaml:"issue_api_key" env-required:"true"`
+	RevokeAPIKey string `yaml:"revoke_api_key" env-required:"true"`
+
+	GetAudit string `yaml:"get_audit" env-required:"true"`
+
+	GraphQL string `yaml:"graphql" env-required:"true"`
+
+	Batch string `yaml:"batch" env-required:"true"`
+
+	Export string `yaml:"export" env-required:"true"`
+
+	// V2 is the prefix of resource-oriented API, its routes are fixed.
+	V2 string `yaml:"v2" env-required:"true"`
 
 	Swagger string `yaml:"swagger" env-required:"true"`
+	Metrics string `yaml:"metrics" env-required:"true"`
 }
 
 type ServiceConfig struct {
 	SwaggerFsRoot string `yaml:"swagger_fs_root" env-required:"true"`
+	RandomSeed    uint64 `yaml:"random_seed" env:"RANDOM_SEED" env-default:"0"`
+
+	MetricsTimeout time.Duration `yaml:"metrics_timeout" env-default:"2s"`
+	HealthTimeout  time.Duration `yaml:"health_timeout" env-default:"1s"`
+
+	MigrateOnStartup bool `yaml:"migrate_on_startup" env:"MIGRATE_ON_STARTUP" env-default:"true"`
+
+	// ValidateRequests rejects requests not matching docs/openapi.yaml, ValidateResponses
+	// logs mismatching responses (debug mode, responses are buffered).
+	ValidateRequests  bool `yaml:"validate_requests" env:"VALIDATE_REQUESTS" env-default:"true"`
+	ValidateResponses bool `yaml:"validate_responses" env:"VALIDATE_RESPONSES" env-default:"false"`
+
+	// IdempotencyTTL is how long responses are replayed for repeated Idempotency-Key,
+	// IdempotencyLease is how long the key is held while the first request is processed.
+	IdempotencyTTL           time.Duration `yaml:"idempotency_ttl" env-default:"24h"`
+	IdempotencyLease         time.Duration `yaml:"idempotency_lease" env-default:"1m"`
+	IdempotencyPurgeInterval time.Duration `yaml:"idempotency_purge_interval" env-default:"1h"`
+
+	// BatchMaxOperations limits the number of operations in one /batch request.
+	BatchMaxOperations int `yaml:"batch_max_operations" env:"BATCH_MAX_OPERATIONS" env-default:"1000"`
+	// ImportMaxRecords limits the number of PRs in one import file.
+	ImportMaxRecords int `yaml:"import_max_records" env:"IMPORT_MAX_RECORDS" env-default:"10000"`
+	// ExportTimeout is the write deadline of export streams, it replaces the server write timeout.
+	ExportTimeout time.Duration `yaml:"export_timeout" env:"EXPORT_TIMEOUT" env-default:"10m"`
+}
+
+type JWTConfig struct {
+	Enabled     bool   `yaml:"enabled" env:"AUTH_JWT_ENABLED" env-default:"false"`
+	UserIDClaim string `yaml:"user_id_claim" env-default:"sub"`
+	TeamClaim   string `yaml:"team_claim" env-default:"team"`
+	RoleClaim   string `yaml:"role_claim" env-default:"role"`
+
+	Verifier jwks.Config `yaml:"verifier"`
+}
+
+type AuthConfig struct {
+	Enabled      bool      `yaml:"enabled" env:"AUTH_ENABLED" env-default:"true"`
+	APIKeyHeader string    `yaml:"api_key_header" env-default:"X-API-Key"`
+	BootstrapKey string    `yaml:"bootstrap_key" env:"AUTH_BOOTSTRAP_KEY"`
+	JWT          JWTConfig `yaml:"jwt"`
+}
+
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
+type StorageConfig struct {
+	// Driver selects repositories implementation: postgres or memory (no persistence, for local runs).
+	Driver string `yaml:"driver" env:"STORAGE_DRIVER" env-default:"postgres"`
+	// TxRetry bounds retries of transactions aborted by concurrent ones (postgres only).
+	TxRetry repository.RetryConfig `yaml:"tx_retry"`
 }
 
 type Config struct {
 	HTTPCfg     pkgConfig.HTTPConfig `yaml:"http"`
+	GRPCCfg     pkgConfig.GRPCConfig `yaml:"grpc"`
 	PostgresCfg postgres.Config      `yaml:"postgres"`
+	StorageCfg  StorageConfig        `yaml:"storage"`
 	PathCfg     PathConfig           `yaml:"paths"`
 	SvcCfg      ServiceConfig        `yaml:"service"`
+	AuthCfg     AuthConfig           `yaml:"auth"`
+	TracingCfg  tracing.Config       `yaml:"tracing"`
+	LogCfg      logger.Config        `yaml:"log"`
 }
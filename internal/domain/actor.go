@@ -0,0 +1,21 @@
+package domain
+
+// Actor is the authenticated client on whose behalf an operation is performed.
+type Actor struct {
+	ID       string
+	Role     AccessRole
+	TeamName string
+}
+
+// CanManageTeam reports whether actor is allowed to change members of the team:
+// admins manage every team, team leads only their own one.
+func (a *Actor) CanManageTeam(teamName string) bool {
+	switch a.Role {
+	case AccessAdmin:
+		return true
+	case AccessTeamLead:
+		return a.TeamName != "" && a.TeamName == teamName
+	}
+
+	return false
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+type AccessRole string
+
+const (
+	AccessAdmin       AccessRole = "admin"
+	AccessTeamLead    AccessRole = "team-lead"
+	AccessMember      AccessRole = "member"
+	AccessIntegration AccessRole = "integration"
+)
+
+func (r AccessRole) IsValid() bool {
+	switch r {
+	case AccessAdmin, AccessTeamLead, AccessMember, AccessIntegration:
+		return true
+	}
+
+	return false
+}
+
+// IsTeamScoped reports whether credentials with this role must belong to some team.
+func (r AccessRole) IsTeamScoped() bool {
+	return r == AccessTeamLead || r == AccessMember
+}
+
+type APIKey struct {
+	ID        string     `json:"id" db:"id"`
+	Name      string     `json:"name" db:"name"`
+	Role      AccessRole `json:"role" db:"role"`
+	TeamName  string     `json:"team_name,omitempty" db:"team_name"`
+	CreatedAt *time.Time `json:"created_at" db:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditTeamCreate     AuditAction = "team.create"
+	AuditTeamDeactivate AuditAction = "team.deactivate"
+	AuditUserSetActive  AuditAction = "user.set_is_active"
+	AuditPRCreate       AuditAction = "pull_request.create"
+	AuditPRMerge        AuditAction = "pull_request.merge"
+	AuditPRReassign     AuditAction = "pull_request.reassign"
+	AuditPRImport       AuditAction = "pull_request.import"
+	AuditAPIKeyIssue    AuditAction = "api_key.issue"
+	AuditAPIKeyRevoke   AuditAction = "api_key.revoke"
+)
+
+type AuditTarget string
+
+const (
+	AuditTargetTeam   AuditTarget = "team"
+	AuditTargetUser   AuditTarget = "user"
+	AuditTargetPR     AuditTarget = "pull_request"
+	AuditTargetAPIKey AuditTarget = "api_key"
+)
+
+type AuditEntry struct {
+	ID         int64           `json:"id" db:"id"`
+	ActorID    string          `json:"actor_id" db:"actor_id"`
+	ActorRole  string          `json:"actor_role" db:"actor_role"`
+	Action     AuditAction     `json:"action" db:"action"`
+	TargetType AuditTarget     `json:"target_type" db:"target_type"`
+	TargetID   string          `json:"target_id" db:"target_id"`
+	Before     json.RawMessage `json:"before,omitempty" db:"before"`
+	After      json.RawMessage `json:"after,omitempty" db:"after"`
+	RequestID  string          `json:"request_id,omitempty" db:"request_id"`
+	CreatedAt  *time.Time      `json:"created_at" db:"created_at"`
+}
+
+// AuditFilter selects audit entries, newest first. Zero fields are not applied,
+// BeforeID is the pagination cursor (ID of the last entry of the previous page).
+type AuditFilter struct {
+	ActorID    string
+	Action     AuditAction
+	TargetType AuditTarget
+	TargetID   string
+	RequestID  string
+	From       *time.Time
+	To         *time.Time
+	BeforeID   int64
+	Limit      int
+}
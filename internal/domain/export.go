@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+type ExportEntity string
+
+const (
+	ExportTeams        ExportEntity = "teams"
+	ExportUsers        ExportEntity = "users"
+	ExportPullRequests ExportEntity = "pull_requests"
+	ExportReviews      ExportEntity = "reviews"
+	// ExportAudit is the audit log, it is a part of backups only and can not be filtered by team.
+	ExportAudit ExportEntity = "audit"
+)
+
+var (
+	// ExportEntities lists all entities in the order they reference each other.
+	ExportEntities = []ExportEntity{ExportTeams, ExportUsers, ExportPullRequests, ExportReviews}
+	// BackupEntities are entities of the full backup, in the order they are restored.
+	BackupEntities = []ExportEntity{ExportTeams, ExportUsers, ExportPullRequests, ExportReviews, ExportAudit}
+)
+
+type ExportFilter struct {
+	// TeamName limits export to the team, its members, PRs authored by them and their reviews.
+	TeamName string
+	// UpdatedSince limits export to rows created or changed at this time or later.
+	UpdatedSince *time.Time
+}
+
+// Review is the assignment of the reviewer to the PR.
+type Review struct {
+	PullRequestID string
+	UserID        string
+}
+
+// ExportRecord is one exported row, only the field of its entity is set.
+type ExportRecord struct {
+	Entity ExportEntity
+	Team   *Team
+	User   *User
+	PR     *PullRequest
+	Review *Review
+	Audit  *AuditEntry
+	// UpdatedAt is the time of the last change of the row, creation time of audit entries.
+	UpdatedAt time.Time
+}
@@ -0,0 +1,23 @@
+package domain
+
+// IdempotencyKey identifies a client request by the key it was sent with. Keys are unique
+// within scope (the client), fingerprint is a hash of what was requested.
+type IdempotencyKey struct {
+	Scope       string
+	Key         string
+	Fingerprint string
+}
+
+// IdempotentResponse is the first response to a request, replayed for its repeats.
+type IdempotentResponse struct {
+	StatusCode int
+	// Headers are the response headers replayed with the body, e.g. Content-Type and ETag.
+	Headers map[string]string
+	Body    []byte
+}
+
+type IdempotencyRecord struct {
+	Fingerprint string
+	// Response is nil while the first request is being processed.
+	Response *IdempotentResponse
+}
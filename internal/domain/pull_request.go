@@ -19,6 +19,8 @@ type PullRequest struct {
 	Reviewers []string   `json:"assigned_reviewers"`
 	CreatedAt *time.Time `json:"createdAt" db:"created_at"`
 	MergedAt  *time.Time `json:"mergedAt" db:"merged_at"`
+	// Version is incremented by every change of the PR and its reviewers.
+	Version int64 `json:"version" db:"version"`
 }
 
 type PullRequestShort struct {
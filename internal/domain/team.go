@@ -3,6 +3,26 @@ package domain
 type Team struct {
 	Name    string  `json:"team_name" db:"name"`
 	Members []*User `json:"members"`
+
+	// RequiredRoles lists roles of which at least one reviewer must be assigned
+	// to every PR of the team (if such a candidate is available).
+	RequiredRoles []UserRole `json:"required_reviewer_roles,omitempty" db:"required_roles"`
+}
+
+// HasRequiredRole reports whether user satisfies the team reviewer rule.
+// Team without required roles is satisfied by anyone.
+func (t *Team) HasRequiredRole(u *User) bool {
+	if len(t.RequiredRoles) == 0 {
+		return true
+	}
+
+	for _, r := range t.RequiredRoles {
+		if u.Role == r {
+			return true
+		}
+	}
+
+	return false
 }
 
 type TeamStats struct {
@@ -10,3 +30,12 @@ type TeamStats struct {
 	Users []*UserStats        `json:"users"`
 	PRs   []*PullRequestStats `json:"open_prs"`
 }
+
+// TeamReviewLoad is a snapshot of open PRs of the team (by author) and open reviews
+// assigned to its members.
+type TeamReviewLoad struct {
+	TeamName        string
+	OpenPRs         int
+	OpenReviews     int
+	UnderstaffedPRs int
+}
@@ -1,9 +1,28 @@
 package domain
 
+type UserRole string
+
+const (
+	RoleJunior UserRole = "junior"
+	RoleMiddle UserRole = "middle"
+	RoleSenior UserRole = "senior"
+	RoleLead   UserRole = "lead"
+)
+
+func (r UserRole) IsValid() bool {
+	switch r {
+	case RoleJunior, RoleMiddle, RoleSenior, RoleLead:
+		return true
+	}
+
+	return false
+}
+
 type User struct {
-	ID       string `json:"user_id" db:"id"`
-	Name     string `json:"username" db:"name"`
-	IsActive bool   `json:"is_active" db:"is_active"`
+	ID       string   `json:"user_id" db:"id"`
+	Name     string   `json:"username" db:"name"`
+	IsActive bool     `json:"is_active" db:"is_active"`
+	Role     UserRole `json:"role" db:"role"`
 
 	TeamName string `json:"team_name,omitempty"`
 }
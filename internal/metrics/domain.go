@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"avito-task/internal/repository"
+	"avito-task/pkg/logger"
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// MinReviewers is the number of reviewers below which open PR is counted as understaffed.
+const MinReviewers = 2
+
+// DomainCollector queries review load of teams on every scrape.
+type DomainCollector struct {
+	prRepo  repository.PullRequestRepo
+	timeout time.Duration
+
+	openPRs         *prometheus.Desc
+	openReviews     *prometheus.Desc
+	understaffedPRs *prometheus.Desc
+	scrapeErrors    prometheus.Counter
+}
+
+func NewDomainCollector(prRepo repository.PullRequestRepo, timeout time.Duration) *DomainCollector {
+	return &DomainCollector{
+		prRepo:  prRepo,
+		timeout: timeout,
+		openPRs: prometheus.NewDesc(
+			"reviewer_open_pull_requests", "Number of open PRs by author team.", []string{"team"}, nil,
+		),
+		openReviews: prometheus.NewDesc(
+			"reviewer_open_reviews", "Number of open PR reviews assigned to team members.", []string{"team"}, nil,
+		),
+		understaffedPRs: prometheus.NewDesc(
+			"reviewer_understaffed_pull_requests",
+			"Number of open PRs with fewer than two reviewers by author team.", []string{"team"}, nil,
+		),
+		scrapeErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "reviewer_domain_scrape_errors_total",
+			Help: "Number of failed queries of domain metrics.",
+		}),
+	}
+}
+
+func (c *DomainCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.openPRs
+	ch <- c.openReviews
+	ch <- c.understaffedPRs
+	c.scrapeErrors.Describe(ch)
+}
+
+func (c *DomainCollector) Collect(ch chan<- prometheus.Metric) {
+	const op = "metrics.DomainCollector.Collect"
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	load, err := c.prRepo.GetTeamsReviewLoad(ctx, MinReviewers)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to collect domain metrics", slog.String("op", op), logger.Err(err))
+		c.scrapeErrors.Inc()
+	}
+
+	for _, l := range load {
+		ch <- prometheus.MustNewConstMetric(c.openPRs, prometheus.GaugeValue, float64(l.OpenPRs), l.TeamName)
+		ch <- prometheus.MustNewConstMetric(c.openReviews, prometheus.GaugeValue, float64(l.OpenReviews), l.TeamName)
+		ch <- prometheus.MustNewConstMetric(c.understaffedPRs, prometheus.GaugeValue, float64(l.UnderstaffedPRs), l.TeamName)
+	}
+
+	c.scrapeErrors.Collect(ch)
+}
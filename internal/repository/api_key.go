@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"avito-task/internal/domain"
+	"context"
+)
+
+type APIKeyRepo interface {
+	Create(ctx context.Context, key *domain.APIKey, keyHash string) (*domain.APIKey, error)
+	GetActiveByHash(ctx context.Context, keyHash string) (*domain.APIKey, error)
+	Revoke(ctx context.Context, id string) (*domain.APIKey, error)
+}
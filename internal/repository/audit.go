@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"avito-task/internal/domain"
+	"context"
+)
+
+type AuditRepo interface {
+	Write(ctx context.Context, entry *domain.AuditEntry) error
+	List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error)
+}
@@ -6,4 +6,7 @@ var (
 	ErrTeamNotExists = errors.New("team not exists")
 	ErrUserNotExists = errors.New("user not exists")
 	ErrPRNotExists = errors.New("PR not exists")
+	ErrAPIKeyNotExists = errors.New("API key not exists")
+	ErrReviewerNotAssigned = errors.New("reviewer is not assigned to PR")
+	ErrTxRetriesExhausted = errors.New("transaction conflicts with concurrent ones, try again later")
 )
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"avito-task/internal/domain"
+	"context"
+)
+
+type ExportRepo interface {
+	// Export calls fn for every row of the entity matching the filter in the order of its key.
+	// Rows are read as fn consumes them, iteration stops at the first error returned by fn.
+	Export(
+		ctx context.Context,
+		entity domain.ExportEntity,
+		filter domain.ExportFilter,
+		fn func(rec *domain.ExportRecord) error,
+	) error
+}
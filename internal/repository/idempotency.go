@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"avito-task/internal/domain"
+	"context"
+	"time"
+)
+
+type IdempotencyRepo interface {
+	// Reserve claims the key for lease. It returns nil if the key was free (or expired),
+	// otherwise the record stored for it is returned and nothing is changed.
+	Reserve(ctx context.Context, key domain.IdempotencyKey, lease time.Duration) (*domain.IdempotencyRecord, error)
+	// Complete stores the response and keeps it for ttl.
+	Complete(ctx context.Context, key domain.IdempotencyKey, resp *domain.IdempotentResponse, ttl time.Duration) error
+	Delete(ctx context.Context, key domain.IdempotencyKey) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
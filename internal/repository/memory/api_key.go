@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/repository"
+	"context"
+	"fmt"
+	"time"
+
+	"avito-task/pkg/database"
+)
+
+type APIKeyRepo struct {
+	store *Store
+}
+
+func NewAPIKeyRepo(store *Store) *APIKeyRepo {
+	return &APIKeyRepo{
+		store: store,
+	}
+}
+
+func (r *APIKeyRepo) Create(ctx context.Context, key *domain.APIKey, keyHash string) (*domain.APIKey, error) {
+	const op = "APIKeyRepo.Create"
+
+	err := r.store.run(ctx, func(data *state) error {
+		if _, ok := data.apiKeys[key.ID]; ok {
+			return database.ErrUniqueViolation
+		}
+
+		for _, rec := range data.apiKeys {
+			if rec.hash == keyHash {
+				return database.ErrUniqueViolation
+			}
+		}
+
+		if _, ok := data.teams[key.TeamName]; key.TeamName != "" && !ok {
+			return repository.ErrTeamNotExists
+		}
+
+		now := time.Now().UTC()
+		key.CreatedAt = &now
+		set(data, data.apiKeys, key.ID, apiKeyRecord{key: *key, hash: keyHash})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepo) GetActiveByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	const op = "APIKeyRepo.GetActiveByHash"
+
+	var key *domain.APIKey
+
+	err := r.store.run(ctx, func(data *state) error {
+		for _, rec := range data.apiKeys {
+			if rec.hash == keyHash && rec.key.RevokedAt == nil {
+				k := rec.key
+				key = &k
+
+				return nil
+			}
+		}
+
+		return repository.ErrAPIKeyNotExists
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepo) Revoke(ctx context.Context, id string) (*domain.APIKey, error) {
+	const op = "APIKeyRepo.Revoke"
+
+	var key domain.APIKey
+
+	err := r.store.run(ctx, func(data *state) error {
+		rec, ok := data.apiKeys[id]
+		if !ok {
+			return repository.ErrAPIKeyNotExists
+		}
+
+		if rec.key.RevokedAt == nil {
+			now := time.Now().UTC()
+			rec.key.RevokedAt = &now
+			set(data, data.apiKeys, id, rec)
+		}
+
+		key = rec.key
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &key, nil
+}
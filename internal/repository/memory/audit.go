@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"avito-task/internal/domain"
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+)
+
+type AuditRepo struct {
+	store *Store
+}
+
+func NewAuditRepo(store *Store) *AuditRepo {
+	return &AuditRepo{
+		store: store,
+	}
+}
+
+func (r *AuditRepo) Write(ctx context.Context, entry *domain.AuditEntry) error {
+	const op = "AuditRepo.Write"
+
+	err := r.store.run(ctx, func(data *state) error {
+		now := time.Now().UTC()
+		data.setAuditSeq(data.auditSeq + 1)
+
+		entry.ID = data.auditSeq
+		entry.CreatedAt = &now
+
+		stored := *entry
+		stored.Before = bytes.Clone(entry.Before)
+		stored.After = bytes.Clone(entry.After)
+		data.appendAudit(stored)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func matchesFilter(e *domain.AuditEntry, filter domain.AuditFilter) bool {
+	switch {
+	case filter.ActorID != "" && e.ActorID != filter.ActorID,
+		filter.Action != "" && e.Action != filter.Action,
+		filter.TargetType != "" && e.TargetType != filter.TargetType,
+		filter.TargetID != "" && e.TargetID != filter.TargetID,
+		filter.RequestID != "" && e.RequestID != filter.RequestID,
+		filter.From != nil && e.CreatedAt.Before(*filter.From),
+		filter.To != nil && !e.CreatedAt.Before(*filter.To),
+		filter.BeforeID > 0 && e.ID >= filter.BeforeID:
+		return false
+	}
+
+	return true
+}
+
+func (r *AuditRepo) List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	const op = "AuditRepo.List"
+
+	entries := []*domain.AuditEntry{}
+
+	err := r.store.run(ctx, func(data *state) error {
+		for i := len(data.audit) - 1; i >= 0 && len(entries) < filter.Limit; i-- {
+			e := data.audit[i]
+
+			if matchesFilter(&e, filter) {
+				entries = append(entries, &e)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"avito-task/internal/domain"
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+type ExportRepo struct {
+	store *Store
+}
+
+func NewExportRepo(store *Store) *ExportRepo {
+	return &ExportRepo{
+		store: store,
+	}
+}
+
+// Export collects matching rows first: the data set is in memory anyway, and fn
+// is called once the store is unlocked, after the transaction carried by ctx.
+func (r *ExportRepo) Export(
+	ctx context.Context,
+	entity domain.ExportEntity,
+	filter domain.ExportFilter,
+	fn func(rec *domain.ExportRecord) error,
+) error {
+	const op = "ExportRepo.Export"
+
+	var records []*domain.ExportRecord
+
+	err := r.store.run(ctx, func(data *state) error {
+		var err error
+		records, err = exportRecords(data, entity, filter)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return r.store.afterTx(ctx, func() error {
+		for _, rec := range records {
+			if err := fn(rec); err != nil {
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+func exportRecords(data *state, entity domain.ExportEntity, filter domain.ExportFilter) ([]*domain.ExportRecord, error) {
+	var records []*domain.ExportRecord
+
+	add := func(rec *domain.ExportRecord, teamName, id, userID string) {
+		if filter.TeamName != "" && teamName != filter.TeamName {
+			return
+		}
+
+		rec.Entity = entity
+		rec.UpdatedAt = data.updated[updateKey{entity: entity, id: id, userID: userID}]
+
+		if filter.UpdatedSince != nil && rec.UpdatedAt.Before(*filter.UpdatedSince) {
+			return
+		}
+
+		records = append(records, rec)
+	}
+
+	switch entity {
+	case domain.ExportTeams:
+		for _, name := range slices.Sorted(maps.Keys(data.teams)) {
+			t := data.teams[name]
+			t.RequiredRoles = slices.Clone(t.RequiredRoles)
+			add(&domain.ExportRecord{Team: &t}, name, name, "")
+		}
+	case domain.ExportUsers:
+		for _, id := range slices.Sorted(maps.Keys(data.users)) {
+			u := data.users[id]
+			add(&domain.ExportRecord{User: &u}, u.TeamName, id, "")
+		}
+	case domain.ExportPullRequests:
+		for _, pr := range sortedPRs(data) {
+			add(&domain.ExportRecord{PR: &pr}, data.users[pr.AuthorID].TeamName, pr.ID, "")
+		}
+	case domain.ExportReviews:
+		for _, pr := range sortedPRs(data) {
+			reviewers := slices.Clone(data.reviewers[pr.ID])
+			slices.SortFunc(reviewers, strings.Compare)
+
+			for _, userID := range reviewers {
+				review := domain.Review{PullRequestID: pr.ID, UserID: userID}
+				add(&domain.ExportRecord{Review: &review}, data.users[pr.AuthorID].TeamName, pr.ID, userID)
+			}
+		}
+	case domain.ExportAudit:
+		if filter.TeamName != "" {
+			return nil, fmt.Errorf("%s can not be filtered by team", entity)
+		}
+
+		for _, e := range data.audit {
+			e.Before, e.After = bytes.Clone(e.Before), bytes.Clone(e.After)
+			rec := domain.ExportRecord{Entity: entity, Audit: &e, UpdatedAt: *e.CreatedAt}
+
+			if filter.UpdatedSince == nil || !rec.UpdatedAt.Before(*filter.UpdatedSince) {
+				records = append(records, &rec)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown entity %q", entity)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"avito-task/internal/domain"
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"time"
+)
+
+type IdempotencyRepo struct {
+	store *Store
+}
+
+func NewIdempotencyRepo(store *Store) *IdempotencyRepo {
+	return &IdempotencyRepo{
+		store: store,
+	}
+}
+
+func (r *IdempotencyRepo) Reserve(
+	ctx context.Context,
+	key domain.IdempotencyKey,
+	lease time.Duration,
+) (*domain.IdempotencyRecord, error) {
+	const op = "IdempotencyRepo.Reserve"
+
+	var existing *domain.IdempotencyRecord
+
+	err := r.store.run(ctx, func(data *state) error {
+		id := idempotencyID{scope: key.Scope, key: key.Key}
+		now := time.Now()
+
+		if rec, ok := data.idemKeys[id]; ok && rec.expiresAt.After(now) {
+			existing = &rec.record
+			return nil
+		}
+
+		set(data, data.idemKeys, id, idempotencyRecord{
+			record:    domain.IdempotencyRecord{Fingerprint: key.Fingerprint},
+			expiresAt: now.Add(lease),
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return existing, nil
+}
+
+func (r *IdempotencyRepo) Complete(
+	ctx context.Context,
+	key domain.IdempotencyKey,
+	resp *domain.IdempotentResponse,
+	ttl time.Duration,
+) error {
+	const op = "IdempotencyRepo.Complete"
+
+	err := r.store.run(ctx, func(data *state) error {
+		id := idempotencyID{scope: key.Scope, key: key.Key}
+
+		rec, ok := data.idemKeys[id]
+		if !ok || rec.record.Fingerprint != key.Fingerprint {
+			return nil
+		}
+
+		stored := *resp
+		stored.Headers = maps.Clone(resp.Headers)
+		stored.Body = bytes.Clone(resp.Body)
+		rec.record.Response = &stored
+		rec.expiresAt = time.Now().Add(ttl)
+		set(data, data.idemKeys, id, rec)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepo) Delete(ctx context.Context, key domain.IdempotencyKey) error {
+	const op = "IdempotencyRepo.Delete"
+
+	err := r.store.run(ctx, func(data *state) error {
+		id := idempotencyID{scope: key.Scope, key: key.Key}
+
+		if rec, ok := data.idemKeys[id]; ok && rec.record.Fingerprint == key.Fingerprint {
+			del(data, data.idemKeys, id)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	const op = "IdempotencyRepo.DeleteExpired"
+
+	var deleted int64
+
+	err := r.store.run(ctx, func(data *state) error {
+		now := time.Now()
+
+		for id, rec := range data.idemKeys {
+			if !rec.expiresAt.After(now) {
+				del(data, data.idemKeys, id)
+				deleted++
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,451 @@
+package memory
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/repository"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"avito-task/pkg/database"
+)
+
+type PullRequestRepo struct {
+	store *Store
+}
+
+func NewPullRequestRepo(store *Store) *PullRequestRepo {
+	return &PullRequestRepo{
+		store: store,
+	}
+}
+
+// sortedPRs returns pull requests ordered by ID for deterministic output.
+func sortedPRs(data *state) []domain.PullRequest {
+	prs := slices.Collect(maps.Values(data.prs))
+
+	slices.SortFunc(prs, func(a, b domain.PullRequest) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return prs
+}
+
+// Reviews ---------------------------------------------------------
+
+func (r *PullRequestRepo) GetReviewers(ctx context.Context, prID string) ([]string, error) {
+	const op = "PullRequestRepo.GetReviewers"
+
+	rw := []string{}
+
+	err := r.store.run(ctx, func(data *state) error {
+		rw = append(rw, data.reviewers[prID]...)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rw, nil
+}
+
+func (r *PullRequestRepo) GetUserReviews(ctx context.Context, id string) ([]*domain.PullRequestShort, error) {
+	const op = "UserRepo.GetReview"
+
+	var prs []*domain.PullRequestShort
+
+	err := r.store.run(ctx, func(data *state) error {
+		for _, pr := range sortedPRs(data) {
+			if !slices.Contains(data.reviewers[pr.ID], id) {
+				continue
+			}
+
+			prs = append(prs, &domain.PullRequestShort{
+				ID:       pr.ID,
+				Name:     pr.Name,
+				AuthorID: pr.AuthorID,
+				Status:   pr.Status,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return prs, nil
+}
+
+func (r *PullRequestRepo) GetUsersReviews(
+	ctx context.Context,
+	ids []string,
+) (map[string][]*domain.PullRequestShort, error) {
+	const op = "PullRequestRepo.GetUsersReviews"
+
+	reviews := make(map[string][]*domain.PullRequestShort, len(ids))
+
+	err := r.store.run(ctx, func(data *state) error {
+		for _, pr := range sortedPRs(data) {
+			for _, id := range data.reviewers[pr.ID] {
+				if !slices.Contains(ids, id) {
+					continue
+				}
+
+				reviews[id] = append(reviews[id], &domain.PullRequestShort{
+					ID:       pr.ID,
+					Name:     pr.Name,
+					AuthorID: pr.AuthorID,
+					Status:   pr.Status,
+				})
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reviews, nil
+}
+
+func (r *PullRequestRepo) AddReviewers(ctx context.Context, prID string, users []*domain.User) error {
+	const op = "PullRequestRepo.AddReviewers"
+
+	err := r.store.run(ctx, func(data *state) error {
+		if _, ok := data.prs[prID]; !ok {
+			return repository.ErrPRNotExists
+		}
+
+		rw := slices.Clone(data.reviewers[prID])
+
+		for _, u := range users {
+			if _, ok := data.users[u.ID]; !ok {
+				return repository.ErrUserNotExists
+			}
+
+			if slices.Contains(rw, u.ID) {
+				return database.ErrUniqueViolation
+			}
+
+			rw = append(rw, u.ID)
+			data.touch(domain.ExportReviews, prID, u.ID)
+		}
+
+		set(data, data.reviewers, prID, rw)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// PRs ------------------------------------------------------------
+
+func (r *PullRequestRepo) GetByID(ctx context.Context, id string) (*domain.PullRequest, error) {
+	const op = "PullRequestRepo.GetByID"
+
+	var pr domain.PullRequest
+
+	err := r.store.run(ctx, func(data *state) error {
+		p, ok := data.prs[id]
+		if !ok {
+			return repository.ErrPRNotExists
+		}
+
+		pr = p
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &pr, nil
+}
+
+func (r *PullRequestRepo) CreatePullRequest(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+	const op = "PullRequestRepo.CreatePullRequest"
+
+	err := r.store.run(ctx, func(data *state) error {
+		if _, ok := data.prs[pr.ID]; ok {
+			return database.ErrUniqueViolation
+		}
+
+		if _, ok := data.users[pr.AuthorID]; !ok {
+			return repository.ErrUserNotExists
+		}
+
+		now := time.Now().UTC()
+		pr.Status = domain.PROpen
+		pr.CreatedAt = &now
+		pr.Version = 1
+
+		set(data, data.prs, pr.ID, domain.PullRequest{
+			ID:        pr.ID,
+			Name:      pr.Name,
+			AuthorID:  pr.AuthorID,
+			Status:    pr.Status,
+			CreatedAt: pr.CreatedAt,
+			Version:   pr.Version,
+		})
+		data.touch(domain.ExportPullRequests, pr.ID, "")
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pr, nil
+}
+
+func (r *PullRequestRepo) ImportPullRequest(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+	const op = "PullRequestRepo.ImportPullRequest"
+
+	err := r.store.run(ctx, func(data *state) error {
+		if _, ok := data.prs[pr.ID]; ok {
+			return database.ErrUniqueViolation
+		}
+
+		if _, ok := data.users[pr.AuthorID]; !ok {
+			return repository.ErrUserNotExists
+		}
+
+		if pr.CreatedAt == nil {
+			now := time.Now().UTC()
+			pr.CreatedAt = &now
+		}
+
+		pr.Version = 1
+
+		set(data, data.prs, pr.ID, domain.PullRequest{
+			ID:        pr.ID,
+			Name:      pr.Name,
+			AuthorID:  pr.AuthorID,
+			Status:    pr.Status,
+			CreatedAt: pr.CreatedAt,
+			MergedAt:  pr.MergedAt,
+			Version:   pr.Version,
+		})
+		data.touch(domain.ExportPullRequests, pr.ID, "")
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pr, nil
+}
+
+func (r *PullRequestRepo) Merge(ctx context.Context, id string) (*domain.PullRequest, error) {
+	const op = "PullRequestRepo.Merge"
+
+	var pr domain.PullRequest
+
+	err := r.store.run(ctx, func(data *state) error {
+		p, ok := data.prs[id]
+		if !ok {
+			return repository.ErrPRNotExists
+		}
+
+		if p.MergedAt == nil {
+			now := time.Now().UTC()
+			p.MergedAt = &now
+		}
+
+		if p.Status != domain.PRMerged {
+			p.Version++
+			data.touch(domain.ExportPullRequests, id, "")
+		}
+
+		p.Status = domain.PRMerged
+		set(data, data.prs, id, p)
+		pr = p
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &pr, nil
+}
+
+func (r *PullRequestRepo) Reassign(ctx context.Context, prID string, prevID string, newID string) (int64, error) {
+	const op = "PullRequestRepo.Reassign"
+
+	var version int64
+
+	err := r.store.run(ctx, func(data *state) error {
+		rw := slices.Clone(data.reviewers[prID])
+
+		idx := slices.Index(rw, prevID)
+		if idx < 0 {
+			return repository.ErrReviewerNotAssigned
+		}
+
+		if slices.Contains(rw, newID) {
+			return database.ErrUniqueViolation
+		}
+
+		rw[idx] = newID
+		set(data, data.reviewers, prID, rw)
+		del(data, data.updated, updateKey{entity: domain.ExportReviews, id: prID, userID: prevID})
+		data.touch(domain.ExportReviews, prID, newID)
+
+		pr := data.prs[prID]
+		pr.Version++
+		set(data, data.prs, prID, pr)
+		data.touch(domain.ExportPullRequests, prID, "")
+		version = pr.Version
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return version, nil
+}
+
+// Stats --------------------------------------------------------
+
+// openReviews counts open PRs assigned to each user.
+func openReviews(data *state) map[string]int {
+	counts := make(map[string]int)
+
+	for prID, rw := range data.reviewers {
+		if data.prs[prID].Status != domain.PROpen {
+			continue
+		}
+
+		for _, userID := range rw {
+			counts[userID]++
+		}
+	}
+
+	return counts
+}
+
+func (r *PullRequestRepo) GetUserReviewsCounts(ctx context.Context, teamName string) ([]*domain.UserStats, error) {
+	const op = "PullRequestRepo.GetUserReviewsCounts"
+
+	stats := []*domain.UserStats{}
+
+	err := r.store.run(ctx, func(data *state) error {
+		counts := openReviews(data)
+
+		for _, u := range teamMembers(data, teamName) {
+			if counts[u.ID] == 0 {
+				continue
+			}
+
+			stats = append(stats, &domain.UserStats{
+				ID:           u.ID,
+				ReviewsCount: counts[u.ID],
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return stats, nil
+}
+
+func (r *PullRequestRepo) GetPRReviewersCounts(ctx context.Context, teamName string) ([]*domain.PullRequestStats, error) {
+	const op = "PullRequestRepo.GetPRReviewersCounts"
+
+	stats := []*domain.PullRequestStats{}
+
+	err := r.store.run(ctx, func(data *state) error {
+		for _, pr := range sortedPRs(data) {
+			if data.users[pr.AuthorID].TeamName != teamName {
+				continue
+			}
+
+			stats = append(stats, &domain.PullRequestStats{
+				ID:             pr.ID,
+				Status:         pr.Status,
+				ReviewersCount: len(data.reviewers[pr.ID]),
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// OPEN goes before MERGED as in the pr_status enum.
+	slices.SortStableFunc(stats, func(a, b *domain.PullRequestStats) int {
+		if a.Status == b.Status {
+			return 0
+		} else if a.Status == domain.PROpen {
+			return -1
+		}
+
+		return 1
+	})
+
+	return stats, nil
+}
+
+func (r *PullRequestRepo) GetTeamsReviewLoad(ctx context.Context, minReviewers int) ([]*domain.TeamReviewLoad, error) {
+	const op = "PullRequestRepo.GetTeamsReviewLoad"
+
+	load := []*domain.TeamReviewLoad{}
+
+	err := r.store.run(ctx, func(data *state) error {
+		byTeam := make(map[string]*domain.TeamReviewLoad, len(data.teams))
+
+		for name := range data.teams {
+			l := &domain.TeamReviewLoad{TeamName: name}
+			byTeam[name] = l
+			load = append(load, l)
+		}
+
+		for _, pr := range data.prs {
+			if pr.Status != domain.PROpen {
+				continue
+			}
+
+			if l, ok := byTeam[data.users[pr.AuthorID].TeamName]; ok {
+				l.OpenPRs++
+
+				if len(data.reviewers[pr.ID]) < minReviewers {
+					l.UnderstaffedPRs++
+				}
+			}
+
+			for _, userID := range data.reviewers[pr.ID] {
+				if l, ok := byTeam[data.users[userID].TeamName]; ok {
+					l.OpenReviews++
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	slices.SortFunc(load, func(a, b *domain.TeamReviewLoad) int {
+		return strings.Compare(a.TeamName, b.TeamName)
+	})
+
+	return load, nil
+}
@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/repository"
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+
+	"avito-task/pkg/database"
+)
+
+type RestoreRepo struct {
+	store *Store
+}
+
+func NewRestoreRepo(store *Store) *RestoreRepo {
+	return &RestoreRepo{
+		store: store,
+	}
+}
+
+func (r *RestoreRepo) IsEmpty(ctx context.Context) (bool, error) {
+	const op = "RestoreRepo.IsEmpty"
+
+	var empty bool
+
+	err := r.store.run(ctx, func(data *state) error {
+		empty = len(data.teams) == 0 && len(data.users) == 0 && len(data.prs) == 0
+
+		for _, e := range data.audit {
+			if e.TargetType != domain.AuditTargetAPIKey {
+				empty = false
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return empty, nil
+}
+
+func (r *RestoreRepo) LastAuditID(ctx context.Context) (int64, error) {
+	const op = "RestoreRepo.LastAuditID"
+
+	var id int64
+
+	err := r.store.run(ctx, func(data *state) error {
+		for _, e := range data.audit {
+			id = max(id, e.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+func (r *RestoreRepo) Restore(ctx context.Context, rec *domain.ExportRecord) error {
+	const op = "RestoreRepo.Restore"
+
+	err := r.store.run(ctx, func(data *state) error {
+		key := updateKey{entity: rec.Entity}
+
+		switch rec.Entity {
+		case domain.ExportTeams:
+			if _, ok := data.teams[rec.Team.Name]; ok {
+				return database.ErrUniqueViolation
+			}
+
+			set(data, data.teams, rec.Team.Name, domain.Team{
+				Name:          rec.Team.Name,
+				RequiredRoles: slices.Clone(rec.Team.RequiredRoles),
+			})
+			key.id = rec.Team.Name
+		case domain.ExportUsers:
+			if _, ok := data.users[rec.User.ID]; ok {
+				return database.ErrUniqueViolation
+			}
+
+			if _, ok := data.teams[rec.User.TeamName]; !ok && rec.User.TeamName != "" {
+				return repository.ErrTeamNotExists
+			}
+
+			set(data, data.users, rec.User.ID, *rec.User)
+			key.id = rec.User.ID
+		case domain.ExportPullRequests:
+			if _, ok := data.prs[rec.PR.ID]; ok {
+				return database.ErrUniqueViolation
+			}
+
+			if _, ok := data.users[rec.PR.AuthorID]; !ok {
+				return repository.ErrUserNotExists
+			}
+
+			pr := *rec.PR
+			pr.Reviewers = nil
+			set(data, data.prs, pr.ID, pr)
+			key.id = pr.ID
+		case domain.ExportReviews:
+			rv := rec.Review
+
+			if _, ok := data.prs[rv.PullRequestID]; !ok {
+				return repository.ErrPRNotExists
+			}
+
+			if _, ok := data.users[rv.UserID]; !ok {
+				return repository.ErrUserNotExists
+			}
+
+			if slices.Contains(data.reviewers[rv.PullRequestID], rv.UserID) {
+				return database.ErrUniqueViolation
+			}
+
+			set(data, data.reviewers, rv.PullRequestID, append(slices.Clone(data.reviewers[rv.PullRequestID]), rv.UserID))
+			key.id, key.userID = rv.PullRequestID, rv.UserID
+		case domain.ExportAudit:
+			e := *rec.Audit
+			e.Before, e.After = bytes.Clone(e.Before), bytes.Clone(e.After)
+			data.appendAudit(e)
+
+			return nil
+		default:
+			return fmt.Errorf("unknown entity %q", rec.Entity)
+		}
+
+		set(data, data.updated, key, rec.UpdatedAt.UTC())
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *RestoreRepo) SyncSequences(ctx context.Context) error {
+	const op = "RestoreRepo.SyncSequences"
+
+	err := r.store.run(ctx, func(data *state) error {
+		seq := data.auditSeq
+		for _, e := range data.audit {
+			seq = max(seq, e.ID)
+		}
+
+		data.setAuditSeq(seq)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
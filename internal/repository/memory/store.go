@@ -0,0 +1,254 @@
+package memory
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/repository"
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrForeignTx = errors.New("transaction belongs to another storage")
+
+type idempotencyID struct {
+	scope string
+	key   string
+}
+
+type idempotencyRecord struct {
+	record    domain.IdempotencyRecord
+	expiresAt time.Time
+}
+
+type apiKeyRecord struct {
+	key  domain.APIKey
+	hash string
+}
+
+// state is the whole data set. Transactions work on it in place and revert
+// their changes by the undo log on rollback.
+type state struct {
+	teams     map[string]domain.Team
+	users     map[string]domain.User
+	prs       map[string]domain.PullRequest
+	reviewers map[string][]string
+	apiKeys   map[string]apiKeyRecord
+	idemKeys  map[idempotencyID]idempotencyRecord
+	audit     []domain.AuditEntry
+	auditSeq  int64
+	// updated keeps the time of the last change of rows for export.
+	updated map[updateKey]time.Time
+	// undo reverts changes of the active transaction, it is nil outside of transactions.
+	undo []func()
+}
+
+// updateKey identifies an exported row: id is team name, user or PR ID; userID is set for reviews.
+type updateKey struct {
+	entity domain.ExportEntity
+	id     string
+	userID string
+}
+
+func newState() *state {
+	return &state{
+		teams:     make(map[string]domain.Team),
+		users:     make(map[string]domain.User),
+		prs:       make(map[string]domain.PullRequest),
+		reviewers: make(map[string][]string),
+		apiKeys:   make(map[string]apiKeyRecord),
+		idemKeys:  make(map[idempotencyID]idempotencyRecord),
+		updated:   make(map[updateKey]time.Time),
+	}
+}
+
+// touch marks the row as changed now.
+func (s *state) touch(entity domain.ExportEntity, id, userID string) {
+	set(s, s.updated, updateKey{entity: entity, id: id, userID: userID}, time.Now().UTC())
+}
+
+// onRollback registers fn reverting a change made by the active transaction.
+func (s *state) onRollback(fn func()) {
+	if s.undo != nil {
+		s.undo = append(s.undo, fn)
+	}
+}
+
+// rollback reverts changes of the active transaction in reverse order.
+func (s *state) rollback() {
+	for i := len(s.undo) - 1; i >= 0; i-- {
+		s.undo[i]()
+	}
+}
+
+// set stores v under k. Values are never mutated in place once stored,
+// so keeping the previous one is enough to revert the change.
+func set[K comparable, V any](s *state, m map[K]V, k K, v V) {
+	remember(s, m, k)
+	m[k] = v
+}
+
+// del deletes k, the previous value is restored on rollback.
+func del[K comparable, V any](s *state, m map[K]V, k K) {
+	remember(s, m, k)
+	delete(m, k)
+}
+
+func remember[K comparable, V any](s *state, m map[K]V, k K) {
+	old, ok := m[k]
+
+	s.onRollback(func() {
+		if ok {
+			m[k] = old
+		} else {
+			delete(m, k)
+		}
+	})
+}
+
+// appendAudit appends the entry to the log.
+func (s *state) appendAudit(e domain.AuditEntry) {
+	n := len(s.audit)
+
+	s.onRollback(func() {
+		s.audit = s.audit[:n]
+	})
+
+	s.audit = append(s.audit, e)
+}
+
+// setAuditSeq moves the generator of audit entry IDs.
+func (s *state) setAuditSeq(seq int64) {
+	prev := s.auditSeq
+
+	s.onRollback(func() {
+		s.auditSeq = prev
+	})
+
+	s.auditSeq = seq
+}
+
+// Store keeps all data in process memory. Transactions are executed one at a time,
+// which makes them trivially serializable for any requested isolation level.
+type Store struct {
+	sem  chan struct{}
+	data *state
+}
+
+func NewStore() *Store {
+	return &Store{
+		sem:  make(chan struct{}, 1),
+		data: newState(),
+	}
+}
+
+type memTx struct {
+	store *Store
+	// deferred are called in order after the transaction is committed and the store
+	// is unlocked.
+	deferred []func() error
+}
+
+type txKey struct{}
+
+func (s *Store) acquire(ctx context.Context) error {
+	select {
+	case s.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Store) release() {
+	<-s.sem
+}
+
+// txFromContext returns the transaction of this store carried by ctx, if any.
+func (s *Store) txFromContext(ctx context.Context) (*memTx, bool) {
+	t, ok := ctx.Value(txKey{}).(*memTx)
+	if !ok {
+		return nil, false
+	}
+
+	return t, t.store == s
+}
+
+// WithinTx implements repository.TxManager. Changes are reverted by the undo log
+// if fn fails or panics.
+func (s *Store) WithinTx(ctx context.Context, _ repository.TxOptions, fn func(ctx context.Context) error) error {
+	if t, ok := ctx.Value(txKey{}).(*memTx); ok {
+		if t.store != s {
+			return ErrForeignTx
+		}
+
+		return fn(ctx)
+	}
+
+	t := &memTx{store: s}
+
+	if err := s.commit(ctx, t, fn); err != nil {
+		return err
+	}
+
+	for _, d := range t.deferred {
+		if err := d(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// commit runs fn under the lock and reverts its changes on failure.
+func (s *Store) commit(ctx context.Context, t *memTx, fn func(ctx context.Context) error) (err error) {
+	if err := s.acquire(ctx); err != nil {
+		return err
+	}
+
+	defer s.release()
+
+	s.data.undo = []func(){}
+
+	defer func() {
+		p := recover()
+		if p != nil || err != nil {
+			s.data.rollback()
+		}
+
+		s.data.undo = nil
+
+		if p != nil {
+			panic(p)
+		}
+	}()
+
+	return fn(context.WithValue(ctx, txKey{}, t))
+}
+
+// run calls fn within the transaction carried by ctx, or on its own waiting for
+// the active transaction to finish. Without a transaction fn must not leave partial
+// changes on error.
+func (s *Store) run(ctx context.Context, fn func(data *state) error) error {
+	if _, ok := s.txFromContext(ctx); ok {
+		return fn(s.data)
+	}
+
+	if err := s.acquire(ctx); err != nil {
+		return err
+	}
+
+	defer s.release()
+
+	return fn(s.data)
+}
+
+// afterTx calls fn once the transaction carried by ctx is committed and the store
+// is unlocked, or right away without a transaction.
+func (s *Store) afterTx(ctx context.Context, fn func() error) error {
+	if t, ok := s.txFromContext(ctx); ok {
+		t.deferred = append(t.deferred, fn)
+		return nil
+	}
+
+	return fn()
+}
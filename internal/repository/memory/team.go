@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/repository"
+	"context"
+	"fmt"
+	"slices"
+)
+
+type TeamRepo struct {
+	store *Store
+}
+
+func NewTeamRepo(store *Store) *TeamRepo {
+	return &TeamRepo{
+		store: store,
+	}
+}
+
+func (r *TeamRepo) CreateTeam(ctx context.Context, team *domain.Team) (bool, error) {
+	const op = "TeamRepo.TryCreateTeam"
+
+	var wasExisting bool
+
+	err := r.store.run(ctx, func(data *state) error {
+		if _, ok := data.teams[team.Name]; ok {
+			wasExisting = true
+
+			return nil
+		}
+
+		set(data, data.teams, team.Name, domain.Team{
+			Name:          team.Name,
+			RequiredRoles: slices.Clone(team.RequiredRoles),
+		})
+		data.touch(domain.ExportTeams, team.Name, "")
+
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return wasExisting, nil
+}
+
+func (r *TeamRepo) GetByName(ctx context.Context, name string) (*domain.Team, error) {
+	const op = "TeamRepo.GetByName"
+
+	var team domain.Team
+
+	err := r.store.run(ctx, func(data *state) error {
+		t, ok := data.teams[name]
+		if !ok {
+			return repository.ErrTeamNotExists
+		}
+
+		team = t
+		team.RequiredRoles = slices.Clone(t.RequiredRoles)
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &team, nil
+}
@@ -0,0 +1,186 @@
+package memory
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/repository"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type UserRepo struct {
+	store *Store
+}
+
+func NewUserRepo(store *Store) *UserRepo {
+	return &UserRepo{
+		store: store,
+	}
+}
+
+func (r *UserRepo) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	const op = "UserRepo.GetByID"
+
+	var user domain.User
+
+	err := r.store.run(ctx, func(data *state) error {
+		u, ok := data.users[id]
+		if !ok {
+			return repository.ErrUserNotExists
+		}
+
+		user = u
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepo) GetByIDs(ctx context.Context, ids []string) ([]*domain.User, error) {
+	const op = "UserRepo.GetByIDs"
+
+	var users []*domain.User
+
+	err := r.store.run(ctx, func(data *state) error {
+		for _, id := range ids {
+			if u, ok := data.users[id]; ok {
+				users = append(users, &u)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+// teamMembers returns members of the team ordered by ID, like the SQL implementation does.
+func teamMembers(data *state, teamName string) []domain.User {
+	var users []domain.User
+
+	for _, u := range data.users {
+		if u.TeamName == teamName {
+			users = append(users, u)
+		}
+	}
+
+	slices.SortFunc(users, func(a, b domain.User) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return users
+}
+
+func (r *UserRepo) GetByTeam(ctx context.Context, opts repository.GetByTeamOpts) ([]*domain.User, error) {
+	const op = "UserRepo.GetByTeam"
+
+	users := []*domain.User{}
+
+	err := r.store.run(ctx, func(data *state) error {
+		for _, u := range teamMembers(data, opts.TeamName) {
+			if opts.OnlyActive && !u.IsActive || slices.Contains(opts.ExcludeIDs, u.ID) {
+				continue
+			}
+
+			if opts.Limit > 0 && len(users) == opts.Limit {
+				break
+			}
+
+			users = append(users, &u)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepo) SetIsActive(ctx context.Context, id string, isActive bool) (*domain.User, error) {
+	const op = "UserRepo.SetIsActive"
+
+	var user domain.User
+
+	err := r.store.run(ctx, func(data *state) error {
+		u, ok := data.users[id]
+		if !ok {
+			return repository.ErrUserNotExists
+		}
+
+		if u.IsActive != isActive {
+			u.IsActive = isActive
+			set(data, data.users, id, u)
+			data.touch(domain.ExportUsers, id, "")
+		}
+
+		user = u
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepo) DeactivateTeam(ctx context.Context, teamName string) ([]*domain.User, error) {
+	const op = "UserRepo.DeactivateTeam"
+
+	users := []*domain.User{}
+
+	err := r.store.run(ctx, func(data *state) error {
+		for _, u := range teamMembers(data, teamName) {
+			if u.IsActive {
+				u.IsActive = false
+				set(data, data.users, u.ID, u)
+				data.touch(domain.ExportUsers, u.ID, "")
+			}
+
+			users = append(users, &u)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepo) UpsertUsers(ctx context.Context, users []*domain.User) error {
+	const op = "UserRepo.UpsertUsers"
+
+	err := r.store.run(ctx, func(data *state) error {
+		for _, u := range users {
+			if _, ok := data.teams[u.TeamName]; !ok {
+				return repository.ErrTeamNotExists
+			}
+		}
+
+		for _, u := range users {
+			if old, ok := data.users[u.ID]; !ok || old != *u {
+				set(data, data.users, u.ID, *u)
+				data.touch(domain.ExportUsers, u.ID, "")
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
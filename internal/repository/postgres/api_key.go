@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+
+	"avito-task/pkg/database"
+	pkgPostgres "avito-task/pkg/database/postgres"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type APIKeyRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewAPIKeyRepo(pool *pgxpool.Pool) *APIKeyRepo {
+	return &APIKeyRepo{
+		pool: pool,
+	}
+}
+
+func (r *APIKeyRepo) Create(ctx context.Context, key *domain.APIKey, keyHash string) (*domain.APIKey, error) {
+	const op = "APIKeyRepo.Create"
+
+	sql := `
+		INSERT INTO api_keys (id, name, key_hash, role, team_name)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		RETURNING created_at`
+
+	if err := querier(ctx, r.pool).QueryRow(
+		ctx, sql, key.ID, key.Name, keyHash, string(key.Role), key.TeamName,
+	).Scan(&key.CreatedAt); err != nil {
+		dbErr := pkgPostgres.DetectError(err)
+
+		if errors.Is(dbErr, database.ErrUniqueViolation) {
+			return nil, fmt.Errorf("%s: %w", op, database.ErrUniqueViolation)
+		} else if errors.Is(dbErr, database.ErrForeignKeyViolation) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrTeamNotExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepo) GetActiveByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	const op = "APIKeyRepo.GetActiveByHash"
+
+	sql := `
+		SELECT id, name, role, COALESCE(team_name, ''), created_at
+		FROM api_keys WHERE key_hash = $1 AND revoked_at IS NULL`
+
+	var key domain.APIKey
+	if err := querier(ctx, r.pool).QueryRow(ctx, sql, keyHash).Scan(
+		&key.ID, &key.Name, &key.Role, &key.TeamName, &key.CreatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrAPIKeyNotExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &key, nil
+}
+
+func (r *APIKeyRepo) Revoke(ctx context.Context, id string) (*domain.APIKey, error) {
+	const op = "APIKeyRepo.Revoke"
+
+	sql := `
+		UPDATE api_keys SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP)
+		WHERE id = $1
+		RETURNING id, name, role, COALESCE(team_name, ''), created_at, revoked_at`
+
+	var key domain.APIKey
+	if err := querier(ctx, r.pool).QueryRow(ctx, sql, id).Scan(
+		&key.ID, &key.Name, &key.Role, &key.TeamName, &key.CreatedAt, &key.RevokedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrAPIKeyNotExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &key, nil
+}
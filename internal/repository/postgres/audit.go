@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"avito-task/internal/domain"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AuditRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewAuditRepo(pool *pgxpool.Pool) *AuditRepo {
+	return &AuditRepo{
+		pool: pool,
+	}
+}
+
+func (r *AuditRepo) Write(ctx context.Context, entry *domain.AuditEntry) error {
+	const op = "AuditRepo.Write"
+
+	sql := `
+		INSERT INTO audit_log (actor_id, actor_role, action, target_type, target_id, before, after, request_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''))
+		RETURNING id, created_at`
+
+	if err := querier(ctx, r.pool).QueryRow(
+		ctx, sql,
+		entry.ActorID, entry.ActorRole, string(entry.Action), string(entry.TargetType), entry.TargetID,
+		nullableJSON(entry.Before), nullableJSON(entry.After), entry.RequestID,
+	).Scan(&entry.ID, &entry.CreatedAt); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func nullableJSON(data []byte) any {
+	if len(data) == 0 {
+		return nil
+	}
+
+	return string(data)
+}
+
+func (r *AuditRepo) List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	const op = "AuditRepo.List"
+
+	sql := `
+		SELECT id, actor_id, actor_role, action, target_type, target_id,
+		before, after, COALESCE(request_id, ''), created_at
+		FROM audit_log WHERE TRUE`
+	args := []any{}
+
+	addCond := func(cond string, arg any) {
+		args = append(args, arg)
+		sql = fmt.Sprintf("%s AND %s $%d", sql, cond, len(args))
+	}
+
+	if filter.ActorID != "" {
+		addCond("actor_id =", filter.ActorID)
+	}
+
+	if filter.Action != "" {
+		addCond("action =", string(filter.Action))
+	}
+
+	if filter.TargetType != "" {
+		addCond("target_type =", string(filter.TargetType))
+	}
+
+	if filter.TargetID != "" {
+		addCond("target_id =", filter.TargetID)
+	}
+
+	if filter.RequestID != "" {
+		addCond("request_id =", filter.RequestID)
+	}
+
+	if filter.From != nil {
+		addCond("created_at >=", *filter.From)
+	}
+
+	if filter.To != nil {
+		addCond("created_at <", *filter.To)
+	}
+
+	if filter.BeforeID > 0 {
+		addCond("id <", filter.BeforeID)
+	}
+
+	args = append(args, filter.Limit)
+	sql = fmt.Sprintf("%s ORDER BY id DESC LIMIT $%d", sql, len(args))
+
+	rows, err := querier(ctx, r.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer rows.Close()
+	entries := []*domain.AuditEntry{}
+
+	for rows.Next() {
+		var e domain.AuditEntry
+		var before, after []byte
+
+		if err = rows.Scan(
+			&e.ID, &e.ActorID, &e.ActorRole, &e.Action, &e.TargetType, &e.TargetID,
+			&before, &after, &e.RequestID, &e.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		e.Before, e.After = before, after
+		entries = append(entries, &e)
+	}
+
+	return entries, nil
+}
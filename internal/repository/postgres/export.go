@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"avito-task/internal/domain"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ExportRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewExportRepo(pool *pgxpool.Pool) *ExportRepo {
+	return &ExportRepo{
+		pool: pool,
+	}
+}
+
+// exportQuery describes how rows of the entity are selected and scanned.
+type exportQuery struct {
+	sql string
+	// teamCond and updatedCond are conditions of the filter, they are followed by the parameter;
+	// empty teamCond means that the entity can not be filtered by team.
+	teamCond    string
+	updatedCond string
+	orderBy     string
+	scan        func(rows pgx.Rows) (*domain.ExportRecord, error)
+}
+
+var exportQueries = map[domain.ExportEntity]exportQuery{
+	domain.ExportTeams: {
+		sql:         "SELECT name, required_roles::text[], updated_at FROM teams WHERE TRUE",
+		teamCond:    "name =",
+		updatedCond: "updated_at >=",
+		orderBy:     "name",
+		scan: func(rows pgx.Rows) (*domain.ExportRecord, error) {
+			rec := domain.ExportRecord{Entity: domain.ExportTeams, Team: &domain.Team{}}
+			var roles []string
+
+			if err := rows.Scan(&rec.Team.Name, &roles, &rec.UpdatedAt); err != nil {
+				return nil, err
+			}
+
+			for _, role := range roles {
+				rec.Team.RequiredRoles = append(rec.Team.RequiredRoles, domain.UserRole(role))
+			}
+
+			return &rec, nil
+		},
+	},
+	domain.ExportUsers: {
+		sql: `
+			SELECT id, name, COALESCE(team_name, ''), is_active, role::text, updated_at
+			FROM users WHERE TRUE`,
+		teamCond:    "team_name =",
+		updatedCond: "updated_at >=",
+		orderBy:     "id",
+		scan: func(rows pgx.Rows) (*domain.ExportRecord, error) {
+			rec := domain.ExportRecord{Entity: domain.ExportUsers, User: &domain.User{}}
+			u := rec.User
+
+			if err := rows.Scan(&u.ID, &u.Name, &u.TeamName, &u.IsActive, &u.Role, &rec.UpdatedAt); err != nil {
+				return nil, err
+			}
+
+			return &rec, nil
+		},
+	},
+	domain.ExportPullRequests: {
+		sql: `
+			SELECT p.id, p.name, COALESCE(p.author_id, ''), p.status::text,
+			p.created_at, p.merged_at, p.version, p.updated_at
+			FROM pull_requests p LEFT JOIN users a ON a.id = p.author_id WHERE TRUE`,
+		teamCond:    "a.team_name =",
+		updatedCond: "p.updated_at >=",
+		orderBy:     "p.id",
+		scan: func(rows pgx.Rows) (*domain.ExportRecord, error) {
+			rec := domain.ExportRecord{Entity: domain.ExportPullRequests, PR: &domain.PullRequest{}}
+			pr := rec.PR
+
+			if err := rows.Scan(
+				&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status,
+				&pr.CreatedAt, &pr.MergedAt, &pr.Version, &rec.UpdatedAt,
+			); err != nil {
+				return nil, err
+			}
+
+			return &rec, nil
+		},
+	},
+	domain.ExportReviews: {
+		sql: `
+			SELECT r.pr_id, r.user_id, r.updated_at
+			FROM reviewers r
+			JOIN pull_requests p ON p.id = r.pr_id
+			LEFT JOIN users a ON a.id = p.author_id WHERE TRUE`,
+		teamCond:    "a.team_name =",
+		updatedCond: "r.updated_at >=",
+		orderBy:     "r.pr_id, r.user_id",
+		scan: func(rows pgx.Rows) (*domain.ExportRecord, error) {
+			rec := domain.ExportRecord{Entity: domain.ExportReviews, Review: &domain.Review{}}
+
+			if err := rows.Scan(&rec.Review.PullRequestID, &rec.Review.UserID, &rec.UpdatedAt); err != nil {
+				return nil, err
+			}
+
+			return &rec, nil
+		},
+	},
+	domain.ExportAudit: {
+		sql: `
+			SELECT id, actor_id, actor_role, action, target_type, target_id,
+			before, after, COALESCE(request_id, ''), created_at
+			FROM audit_log WHERE TRUE`,
+		updatedCond: "created_at >=",
+		orderBy:     "id",
+		scan: func(rows pgx.Rows) (*domain.ExportRecord, error) {
+			rec := domain.ExportRecord{Entity: domain.ExportAudit, Audit: &domain.AuditEntry{}}
+			e := rec.Audit
+			var before, after []byte
+
+			if err := rows.Scan(
+				&e.ID, &e.ActorID, &e.ActorRole, &e.Action, &e.TargetType, &e.TargetID,
+				&before, &after, &e.RequestID, &rec.UpdatedAt,
+			); err != nil {
+				return nil, err
+			}
+
+			createdAt := rec.UpdatedAt
+			e.Before, e.After, e.CreatedAt = before, after, &createdAt
+
+			return &rec, nil
+		},
+	},
+}
+
+func (r *ExportRepo) Export(
+	ctx context.Context,
+	entity domain.ExportEntity,
+	filter domain.ExportFilter,
+	fn func(rec *domain.ExportRecord) error,
+) error {
+	const op = "ExportRepo.Export"
+
+	q, ok := exportQueries[entity]
+	if !ok {
+		return fmt.Errorf("%s: unknown entity %q", op, entity)
+	}
+
+	sql := q.sql
+	args := []any{}
+
+	addCond := func(cond string, arg any) {
+		args = append(args, arg)
+		sql = fmt.Sprintf("%s AND %s $%d", sql, cond, len(args))
+	}
+
+	if filter.TeamName != "" {
+		if q.teamCond == "" {
+			return fmt.Errorf("%s: %s can not be filtered by team", op, entity)
+		}
+
+		addCond(q.teamCond, filter.TeamName)
+	}
+
+	if filter.UpdatedSince != nil {
+		// Timestamps are stored in UTC without time zone.
+		addCond(q.updatedCond, filter.UpdatedSince.In(time.UTC))
+	}
+
+	sql = fmt.Sprintf("%s ORDER BY %s", sql, q.orderBy)
+
+	rows, err := querier(ctx, r.pool).Query(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		rec, err := q.scan(rows)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if err = fn(rec); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"avito-task/internal/domain"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type IdempotencyRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewIdempotencyRepo(pool *pgxpool.Pool) *IdempotencyRepo {
+	return &IdempotencyRepo{
+		pool: pool,
+	}
+}
+
+func (r *IdempotencyRepo) Reserve(
+	ctx context.Context,
+	key domain.IdempotencyKey,
+	lease time.Duration,
+) (*domain.IdempotencyRecord, error) {
+	const op = "IdempotencyRepo.Reserve"
+
+	// Expired records are taken over as if there were none.
+	sql := `
+		INSERT INTO idempotency_keys (scope, key, fingerprint, expires_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP + make_interval(secs => $4))
+		ON CONFLICT (scope, key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint,
+		    status_code = NULL,
+		    headers = NULL,
+		    body = NULL,
+		    created_at = CURRENT_TIMESTAMP,
+		    expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= CURRENT_TIMESTAMP`
+
+	tag, err := querier(ctx, r.pool).Exec(ctx, sql, key.Scope, key.Key, key.Fingerprint, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		return nil, nil
+	}
+
+	sql = `
+		SELECT fingerprint, status_code, headers, body
+		FROM idempotency_keys WHERE scope = $1 AND key = $2`
+
+	var (
+		rec        domain.IdempotencyRecord
+		statusCode *int
+		resp       domain.IdempotentResponse
+	)
+
+	if err = querier(ctx, r.pool).QueryRow(ctx, sql, key.Scope, key.Key).Scan(
+		&rec.Fingerprint, &statusCode, &resp.Headers, &resp.Body,
+	); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if statusCode != nil {
+		resp.StatusCode = *statusCode
+		rec.Response = &resp
+	}
+
+	return &rec, nil
+}
+
+func (r *IdempotencyRepo) Complete(
+	ctx context.Context,
+	key domain.IdempotencyKey,
+	resp *domain.IdempotentResponse,
+	ttl time.Duration,
+) error {
+	const op = "IdempotencyRepo.Complete"
+
+	sql := `
+		UPDATE idempotency_keys
+		SET status_code = $4, headers = $5, body = $6,
+		    expires_at = CURRENT_TIMESTAMP + make_interval(secs => $7)
+		WHERE scope = $1 AND key = $2 AND fingerprint = $3`
+
+	if _, err := querier(ctx, r.pool).Exec(
+		ctx, sql, key.Scope, key.Key, key.Fingerprint, resp.StatusCode, resp.Headers, resp.Body, ttl.Seconds(),
+	); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepo) Delete(ctx context.Context, key domain.IdempotencyKey) error {
+	const op = "IdempotencyRepo.Delete"
+
+	sql := `DELETE FROM idempotency_keys WHERE scope = $1 AND key = $2 AND fingerprint = $3`
+
+	if _, err := querier(ctx, r.pool).Exec(ctx, sql, key.Scope, key.Key, key.Fingerprint); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepo) DeleteExpired(ctx context.Context) (int64, error) {
+	const op = "IdempotencyRepo.DeleteExpired"
+
+	sql := `DELETE FROM idempotency_keys WHERE expires_at <= CURRENT_TIMESTAMP`
+
+	tag, err := querier(ctx, r.pool).Exec(ctx, sql)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -26,12 +26,12 @@ func NewPullRequestRepo(pool *pgxpool.Pool) *PullRequestRepo {
 
 // Reviews ---------------------------------------------------------
 
-func (r *PullRequestRepo) GetReviewers(ctx context.Context, tx pgx.Tx, prID string) ([]string, error) {
+func (r *PullRequestRepo) GetReviewers(ctx context.Context, prID string) ([]string, error) {
 	const op = "PullRequestRepo.GetReviewers"
 
 	sql := "SELECT user_id FROM reviewers WHERE pr_id = $1"
 
-	rows, err := tx.Query(ctx, sql, prID)
+	rows, err := querier(ctx, r.pool).Query(ctx, sql, prID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -61,7 +61,7 @@ func (r *PullRequestRepo) GetUserReviews(ctx context.Context, id string) ([]*dom
 		JOIN pull_requests p ON r.pr_id = p.id
 		WHERE r.user_id = $1`
 
-	rows, err := r.pool.Query(ctx, sql, id)
+	rows, err := querier(ctx, r.pool).Query(ctx, sql, id)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -82,7 +82,44 @@ func (r *PullRequestRepo) GetUserReviews(ctx context.Context, id string) ([]*dom
 	return prs, nil
 }
 
-func (r *PullRequestRepo) AddReviewers(ctx context.Context, tx pgx.Tx, prID string, users []*domain.User) error {
+func (r *PullRequestRepo) GetUsersReviews(
+	ctx context.Context,
+	ids []string,
+) (map[string][]*domain.PullRequestShort, error) {
+	const op = "PullRequestRepo.GetUsersReviews"
+
+	sql := `
+		SELECT r.user_id, p.id, p.name, p.author_id, p.status
+		FROM reviewers r
+		JOIN pull_requests p ON r.pr_id = p.id
+		WHERE r.user_id = ANY($1)
+		ORDER BY r.user_id, p.id`
+
+	rows, err := querier(ctx, r.pool).Query(ctx, sql, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer rows.Close()
+	reviews := make(map[string][]*domain.PullRequestShort, len(ids))
+
+	for rows.Next() {
+		var (
+			userID string
+			pr     domain.PullRequestShort
+		)
+
+		if err = rows.Scan(&userID, &pr.ID, &pr.Name, &pr.AuthorID, &pr.Status); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		reviews[userID] = append(reviews[userID], &pr)
+	}
+
+	return reviews, nil
+}
+
+func (r *PullRequestRepo) AddReviewers(ctx context.Context, prID string, users []*domain.User) error {
 	const op = "PullRequestRepo.AddReviewers"
 
 	sql := "INSERT INTO reviewers (pr_id, user_id) VALUES %s"
@@ -103,7 +140,7 @@ func (r *PullRequestRepo) AddReviewers(ctx context.Context, tx pgx.Tx, prID stri
 
 	sql = fmt.Sprintf(sql, values)
 
-	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+	if _, err := querier(ctx, r.pool).Exec(ctx, sql, args...); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -112,16 +149,16 @@ func (r *PullRequestRepo) AddReviewers(ctx context.Context, tx pgx.Tx, prID stri
 
 // PRs ------------------------------------------------------------
 
-func (r *PullRequestRepo) GetByID(ctx context.Context, tx pgx.Tx, id string) (*domain.PullRequest, error) {
+func (r *PullRequestRepo) GetByID(ctx context.Context, id string) (*domain.PullRequest, error) {
 	const op = "PullRequestRepo.GetByID"
 
 	sql := `
-		SELECT id, name, author_id, status, created_at, COALESCE(merged_at, '0001-01-01'::date)
+		SELECT id, name, author_id, status, created_at, COALESCE(merged_at, '0001-01-01'::date), version
 		FROM pull_requests WHERE id = $1`
 
 	var pr domain.PullRequest
-	if err := tx.QueryRow(ctx, sql, id).Scan(
-		&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt,
+	if err := querier(ctx, r.pool).QueryRow(ctx, sql, id).Scan(
+		&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt, &pr.Version,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("%s: %w", op, repository.ErrPRNotExists)
@@ -137,17 +174,17 @@ func (r *PullRequestRepo) GetByID(ctx context.Context, tx pgx.Tx, id string) (*d
 	return &pr, nil
 }
 
-func (r *PullRequestRepo) CreatePullRequest(ctx context.Context, tx pgx.Tx, pr *domain.PullRequest) (*domain.PullRequest, error) {
+func (r *PullRequestRepo) CreatePullRequest(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
 	const op = "PullRequestRepo.CreatePullRequest"
 
 	sql := `
 		INSERT INTO pull_requests (id, name, author_id)
 		VALUES ($1, $2, $3)
-		RETURNING status, created_at`
+		RETURNING status, created_at, version`
 
-	if err := tx.QueryRow(
+	if err := querier(ctx, r.pool).QueryRow(
 		ctx, sql, pr.ID, pr.Name, pr.AuthorID,
-	).Scan(&pr.Status, &pr.CreatedAt); err != nil {
+	).Scan(&pr.Status, &pr.CreatedAt, &pr.Version); err != nil {
 		dbErr := pkgPostgres.DetectError(err)
 
 		if errors.Is(dbErr, database.ErrUniqueViolation) {
@@ -162,19 +199,45 @@ func (r *PullRequestRepo) CreatePullRequest(ctx context.Context, tx pgx.Tx, pr *
 	return pr, nil
 }
 
-func (r *PullRequestRepo) Merge(ctx context.Context, tx pgx.Tx, id string) (*domain.PullRequest, error) {
+func (r *PullRequestRepo) ImportPullRequest(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+	const op = "PullRequestRepo.ImportPullRequest"
+
+	sql := `
+		INSERT INTO pull_requests (id, name, author_id, status, created_at, merged_at)
+		VALUES ($1, $2, $3, $4::text::pr_status, COALESCE($5, CURRENT_TIMESTAMP), $6)
+		RETURNING created_at, version`
+
+	if err := querier(ctx, r.pool).QueryRow(
+		ctx, sql, pr.ID, pr.Name, pr.AuthorID, string(pr.Status), pr.CreatedAt, pr.MergedAt,
+	).Scan(&pr.CreatedAt, &pr.Version); err != nil {
+		dbErr := pkgPostgres.DetectError(err)
+
+		if errors.Is(dbErr, database.ErrUniqueViolation) {
+			return nil, fmt.Errorf("%s: %w", op, database.ErrUniqueViolation)
+		} else if errors.Is(dbErr, database.ErrForeignKeyViolation) {
+			return nil, fmt.Errorf("%s: %w", op, repository.ErrUserNotExists)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return pr, nil
+}
+
+func (r *PullRequestRepo) Merge(ctx context.Context, id string) (*domain.PullRequest, error) {
 	const op = "PullRequestRepo.Merge"
 
 	sql := `
 		UPDATE pull_requests SET
 		status = 'MERGED',
-		merged_at = COALESCE(merged_at, CURRENT_TIMESTAMP)
+		merged_at = COALESCE(merged_at, CURRENT_TIMESTAMP),
+		version = version + CASE WHEN status = 'MERGED' THEN 0 ELSE 1 END
 		WHERE id = $1
-		RETURNING id, name, author_id, status, created_at, merged_at`
+		RETURNING id, name, author_id, status, created_at, merged_at, version`
 
 	var pr domain.PullRequest
-	if err := tx.QueryRow(ctx, sql, id).Scan(
-		&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt,
+	if err := querier(ctx, r.pool).QueryRow(ctx, sql, id).Scan(
+		&pr.ID, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt, &pr.Version,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("%s: %w", op, repository.ErrPRNotExists)
@@ -190,30 +253,36 @@ func (r *PullRequestRepo) Merge(ctx context.Context, tx pgx.Tx, id string) (*dom
 	return &pr, nil
 }
 
-func (r *PullRequestRepo) Reassign(ctx context.Context, tx pgx.Tx, prID string, prevID string, newID string) error {
+func (r *PullRequestRepo) Reassign(ctx context.Context, prID string, prevID string, newID string) (int64, error) {
 	const op = "PullRequestRepo.Reassign"
 	
 	sql := `
-		UPDATE reviewers SET user_id = $1
-		WHERE user_id = $2 AND pr_id = $3
-		RETURNING user_id`
+		WITH replaced AS (
+			UPDATE reviewers SET user_id = $1
+			WHERE user_id = $2 AND pr_id = $3
+			RETURNING pr_id
+		)
+		UPDATE pull_requests SET version = version + 1
+		WHERE id = (SELECT pr_id FROM replaced)
+		RETURNING version`
 
-	if err := tx.QueryRow(
+	var version int64
+	if err := querier(ctx, r.pool).QueryRow(
 		ctx, sql, newID, prevID, prID,
-	).Scan(&newID); err != nil {
+	).Scan(&version); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return fmt.Errorf("%s: %w", op, pgx.ErrNoRows)
+			return 0, fmt.Errorf("%s: %w", op, repository.ErrReviewerNotAssigned)
 		}
 
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return version, nil
 }
 
 // Stats --------------------------------------------------------
 
-func (r *PullRequestRepo) GetUserReviewsCounts(ctx context.Context, tx pgx.Tx, teamName string) ([]*domain.UserStats, error) {
+func (r *PullRequestRepo) GetUserReviewsCounts(ctx context.Context, teamName string) ([]*domain.UserStats, error) {
 	const op = "PullRequestRepo.GetUserReviewsCounts"
 
 	sql := `
@@ -223,7 +292,7 @@ func (r *PullRequestRepo) GetUserReviewsCounts(ctx context.Context, tx pgx.Tx, t
 		WHERE u.team_name = $1
 		GROUP BY u.id`
 
-	rows, err := tx.Query(ctx, sql, teamName)
+	rows, err := querier(ctx, r.pool).Query(ctx, sql, teamName)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -244,7 +313,7 @@ func (r *PullRequestRepo) GetUserReviewsCounts(ctx context.Context, tx pgx.Tx, t
 	return stats, nil
 }
 
-func (r *PullRequestRepo) GetPRReviewersCounts(ctx context.Context, tx pgx.Tx, teamName string) ([]*domain.PullRequestStats, error) {
+func (r *PullRequestRepo) GetPRReviewersCounts(ctx context.Context, teamName string) ([]*domain.PullRequestStats, error) {
 	const op = "PullRequestRepo.GetPRReviewersCounts"
 
 	sql := `
@@ -258,7 +327,7 @@ func (r *PullRequestRepo) GetPRReviewersCounts(ctx context.Context, tx pgx.Tx, t
 		GROUP BY p.id, p.status
 		ORDER BY p.status`
 
-	rows, err := tx.Query(ctx, sql, teamName)
+	rows, err := querier(ctx, r.pool).Query(ctx, sql, teamName)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -278,3 +347,46 @@ func (r *PullRequestRepo) GetPRReviewersCounts(ctx context.Context, tx pgx.Tx, t
 
 	return stats, nil
 }
+
+func (r *PullRequestRepo) GetTeamsReviewLoad(ctx context.Context, minReviewers int) ([]*domain.TeamReviewLoad, error) {
+	const op = "PullRequestRepo.GetTeamsReviewLoad"
+
+	sql := `
+		WITH open_prs AS (
+			SELECT p.id, u.team_name, COUNT(r.pr_id) AS reviewers FROM pull_requests p
+			JOIN users u ON p.author_id = u.id
+			LEFT JOIN reviewers r ON p.id = r.pr_id
+			WHERE p.status = 'OPEN'
+			GROUP BY p.id, u.team_name
+		), open_reviews AS (
+			SELECT u.team_name, COUNT(*) AS reviews FROM reviewers r
+			JOIN pull_requests p ON r.pr_id = p.id AND p.status = 'OPEN'
+			JOIN users u ON r.user_id = u.id
+			GROUP BY u.team_name
+		)
+		SELECT t.name,
+			(SELECT COUNT(*) FROM open_prs p WHERE p.team_name = t.name),
+			COALESCE((SELECT reviews FROM open_reviews o WHERE o.team_name = t.name), 0),
+			(SELECT COUNT(*) FROM open_prs p WHERE p.team_name = t.name AND p.reviewers < $1)
+		FROM teams t`
+
+	rows, err := querier(ctx, r.pool).Query(ctx, sql, minReviewers)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer rows.Close()
+	load := []*domain.TeamReviewLoad{}
+
+	for rows.Next() {
+		var l domain.TeamReviewLoad
+
+		if err = rows.Scan(&l.TeamName, &l.OpenPRs, &l.OpenReviews, &l.UnderstaffedPRs); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		load = append(load, &l)
+	}
+
+	return load, nil
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"avito-task/internal/domain"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RestoreRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewRestoreRepo(pool *pgxpool.Pool) *RestoreRepo {
+	return &RestoreRepo{
+		pool: pool,
+	}
+}
+
+func (r *RestoreRepo) IsEmpty(ctx context.Context) (bool, error) {
+	const op = "RestoreRepo.IsEmpty"
+
+	sql := `
+		SELECT NOT EXISTS (SELECT 1 FROM teams)
+		AND NOT EXISTS (SELECT 1 FROM users)
+		AND NOT EXISTS (SELECT 1 FROM pull_requests)
+		AND NOT EXISTS (SELECT 1 FROM audit_log WHERE target_type <> 'api_key')`
+
+	var empty bool
+	if err := querier(ctx, r.pool).QueryRow(ctx, sql).Scan(&empty); err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return empty, nil
+}
+
+func (r *RestoreRepo) LastAuditID(ctx context.Context) (int64, error) {
+	const op = "RestoreRepo.LastAuditID"
+
+	var id int64
+	if err := querier(ctx, r.pool).QueryRow(ctx, "SELECT COALESCE(MAX(id), 0) FROM audit_log").Scan(&id); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return id, nil
+}
+
+// utc converts the time to UTC, since timestamps are stored without time zone.
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	u := t.UTC()
+
+	return &u
+}
+
+func (r *RestoreRepo) Restore(ctx context.Context, rec *domain.ExportRecord) error {
+	const op = "RestoreRepo.Restore"
+
+	var (
+		sql  string
+		args []any
+	)
+
+	updatedAt := rec.UpdatedAt.UTC()
+
+	switch rec.Entity {
+	case domain.ExportTeams:
+		sql = "INSERT INTO teams (name, required_roles, updated_at) VALUES ($1, $2::text[]::user_role[], $3)"
+
+		roles := make([]string, 0, len(rec.Team.RequiredRoles))
+		for _, role := range rec.Team.RequiredRoles {
+			roles = append(roles, string(role))
+		}
+
+		args = []any{rec.Team.Name, roles, updatedAt}
+	case domain.ExportUsers:
+		sql = `
+			INSERT INTO users (id, name, team_name, is_active, role, updated_at)
+			VALUES ($1, $2, NULLIF($3, ''), $4, $5::text::user_role, $6)`
+
+		u := rec.User
+		args = []any{u.ID, u.Name, u.TeamName, u.IsActive, string(u.Role), updatedAt}
+	case domain.ExportPullRequests:
+		sql = `
+			INSERT INTO pull_requests (id, name, author_id, status, created_at, merged_at, version, updated_at)
+			VALUES ($1, $2, NULLIF($3, ''), $4::text::pr_status, $5, $6, $7, $8)`
+
+		pr := rec.PR
+		args = []any{pr.ID, pr.Name, pr.AuthorID, string(pr.Status), utc(pr.CreatedAt), utc(pr.MergedAt), pr.Version, updatedAt}
+	case domain.ExportReviews:
+		sql = "INSERT INTO reviewers (pr_id, user_id, updated_at) VALUES ($1, $2, $3)"
+		args = []any{rec.Review.PullRequestID, rec.Review.UserID, updatedAt}
+	case domain.ExportAudit:
+		sql = `
+			INSERT INTO audit_log (id, actor_id, actor_role, action, target_type, target_id, before, after, request_id, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), $10)`
+
+		e := rec.Audit
+		args = []any{
+			e.ID, e.ActorID, e.ActorRole, string(e.Action), string(e.TargetType), e.TargetID,
+			nullableJSON(e.Before), nullableJSON(e.After), e.RequestID, utc(e.CreatedAt),
+		}
+	default:
+		return fmt.Errorf("%s: unknown entity %q", op, rec.Entity)
+	}
+
+	if _, err := querier(ctx, r.pool).Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (r *RestoreRepo) SyncSequences(ctx context.Context) error {
+	const op = "RestoreRepo.SyncSequences"
+
+	sql := "SELECT setval(pg_get_serial_sequence('audit_log', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM audit_log"
+
+	if _, err := querier(ctx, r.pool).Exec(ctx, sql); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
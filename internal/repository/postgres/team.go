@@ -21,29 +21,36 @@ func NewTeamRepo(pool *pgxpool.Pool) *TeamRepo {
 	}
 }
 
-func (r *TeamRepo) CreateTeam(ctx context.Context, tx pgx.Tx, team *domain.Team) (bool, error) {
+func (r *TeamRepo) CreateTeam(ctx context.Context, team *domain.Team) (bool, error) {
 	const op = "TeamRepo.TryCreateTeam"
 
 	sql := `
-		INSERT INTO teams (name) VALUES ($1)
+		INSERT INTO teams (name, required_roles) VALUES ($1, $2::text[]::user_role[])
 		ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
 		RETURNING (xmax <> 0)`
 
+	roles := make([]string, 0, len(team.RequiredRoles))
+	for _, role := range team.RequiredRoles {
+		roles = append(roles, string(role))
+	}
+
 	var wasExisting bool
-	if err := tx.QueryRow(ctx, sql, team.Name).Scan(&wasExisting); err != nil {
+	if err := querier(ctx, r.pool).QueryRow(ctx, sql, team.Name, roles).Scan(&wasExisting); err != nil {
 		return false, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return wasExisting, nil
 }
 
-func (r *TeamRepo) GetByName(ctx context.Context, tx pgx.Tx, name string) (*domain.Team, error) {
+func (r *TeamRepo) GetByName(ctx context.Context, name string) (*domain.Team, error) {
 	const op = "TeamRepo.GetByName"
 
-	sql := "SELECT name FROM teams WHERE name = $1"
+	sql := "SELECT name, required_roles::text[] FROM teams WHERE name = $1"
 
 	var team domain.Team
-	if err := tx.QueryRow(ctx, sql, name).Scan(&team.Name); err != nil {
+	var roles []string
+
+	if err := querier(ctx, r.pool).QueryRow(ctx, sql, name).Scan(&team.Name, &roles); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("%s: %w", op, repository.ErrTeamNotExists)
 		}
@@ -51,5 +58,9 @@ func (r *TeamRepo) GetByName(ctx context.Context, tx pgx.Tx, name string) (*doma
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	for _, role := range roles {
+		team.RequiredRoles = append(team.RequiredRoles, domain.UserRole(role))
+	}
+
 	return &team, nil
 }
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"avito-task/internal/repository"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type txKey struct{}
+
+type TxManager struct {
+	pool *pgxpool.Pool
+}
+
+func NewTxManager(pool *pgxpool.Pool) *TxManager {
+	return &TxManager{
+		pool: pool,
+	}
+}
+
+// WithinTx implements repository.TxManager.
+func (m *TxManager) WithinTx(ctx context.Context, opts repository.TxOptions, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	txOpts := pgx.TxOptions{
+		IsoLevel: pgx.TxIsoLevel(opts.IsoLevel),
+	}
+
+	if opts.ReadOnly {
+		txOpts.AccessMode = pgx.ReadOnly
+	}
+
+	tx, err := m.pool.BeginTx(ctx, txOpts)
+	if err != nil {
+		return fmt.Errorf("failed to begin tx: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit tx: %w", err)
+	}
+
+	return nil
+}
+
+type dbtx interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// querier returns the transaction carried by ctx or the pool when there is none.
+func querier(ctx context.Context, pool *pgxpool.Pool) dbtx {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+
+	return pool
+}
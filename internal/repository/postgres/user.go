@@ -21,14 +21,14 @@ func NewUserRepo(pool *pgxpool.Pool) *UserRepo {
 	}
 }
 
-func (r *UserRepo) GetByID(ctx context.Context, tx pgx.Tx, id string) (*domain.User, error) {
+func (r *UserRepo) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	const op = "UserRepo.GetByID"
 
-	sql := "SELECT id, name, team_name, is_active FROM users WHERE id = $1"
+	sql := "SELECT id, name, team_name, is_active, role FROM users WHERE id = $1"
 
 	var user domain.User
-	if err := tx.QueryRow(ctx, sql, id).Scan(
-		&user.ID, &user.Name, &user.TeamName, &user.IsActive,
+	if err := querier(ctx, r.pool).QueryRow(ctx, sql, id).Scan(
+		&user.ID, &user.Name, &user.TeamName, &user.IsActive, &user.Role,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("%s: %w", op, repository.ErrUserNotExists)
@@ -40,10 +40,36 @@ func (r *UserRepo) GetByID(ctx context.Context, tx pgx.Tx, id string) (*domain.U
 	return &user, nil
 }
 
-func (r *UserRepo) GetByTeam(ctx context.Context, tx pgx.Tx, opts repository.GetByTeamOpts) ([]*domain.User, error) {
+func (r *UserRepo) GetByIDs(ctx context.Context, ids []string) ([]*domain.User, error) {
+	const op = "UserRepo.GetByIDs"
+
+	sql := "SELECT id, name, team_name, is_active, role FROM users WHERE id = ANY($1)"
+
+	rows, err := querier(ctx, r.pool).Query(ctx, sql, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	defer rows.Close()
+	var users []*domain.User
+
+	for rows.Next() {
+		var user domain.User
+
+		if err = rows.Scan(&user.ID, &user.Name, &user.TeamName, &user.IsActive, &user.Role); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		users = append(users, &user)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepo) GetByTeam(ctx context.Context, opts repository.GetByTeamOpts) ([]*domain.User, error) {
 	const op = "UserRepo.GetByTeam"
 	
-	sql := "SELECT id, name, team_name, is_active FROM users WHERE team_name = $1"
+	sql := "SELECT id, name, team_name, is_active, role FROM users WHERE team_name = $1"
 	args := []any{opts.TeamName}
 	i := 2
 
@@ -57,12 +83,14 @@ func (r *UserRepo) GetByTeam(ctx context.Context, tx pgx.Tx, opts repository.Get
 		i++
 	}
 
+	sql = fmt.Sprintf("%s ORDER BY id", sql)
+
 	if opts.Limit > 0 {
-		sql = fmt.Sprintf("%s ORDER BY RANDOM() LIMIT $%d", sql, i)
+		sql = fmt.Sprintf("%s LIMIT $%d", sql, i)
 		args = append(args, opts.Limit)
 	}
 
-	rows, err := tx.Query(ctx, sql, args...)
+	rows, err := querier(ctx, r.pool).Query(ctx, sql, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -73,7 +101,7 @@ func (r *UserRepo) GetByTeam(ctx context.Context, tx pgx.Tx, opts repository.Get
 	for rows.Next() {
 		var u domain.User
 		
-		if err = rows.Scan(&u.ID, &u.Name, &u.TeamName, &u.IsActive); err != nil {
+		if err = rows.Scan(&u.ID, &u.Name, &u.TeamName, &u.IsActive, &u.Role); err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 		
@@ -88,12 +116,12 @@ func (r *UserRepo) SetIsActive(ctx context.Context, id string, isActive bool) (*
 	
 	sql := `
 		UPDATE users SET is_active = $1 WHERE id = $2
-		RETURNING id, name, team_name, is_active`
+		RETURNING id, name, team_name, is_active, role`
 	
-	row := r.pool.QueryRow(ctx, sql, isActive, id)
+	row := querier(ctx, r.pool).QueryRow(ctx, sql, isActive, id)
 	var user domain.User
 	
-	if err := row.Scan(&user.ID, &user.Name, &user.TeamName, &user.IsActive); err != nil {
+	if err := row.Scan(&user.ID, &user.Name, &user.TeamName, &user.IsActive, &user.Role); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, fmt.Errorf("%s: %w", op, repository.ErrUserNotExists)
 		}
@@ -104,14 +132,14 @@ func (r *UserRepo) SetIsActive(ctx context.Context, id string, isActive bool) (*
 	return &user, nil
 }
 
-func (r *UserRepo) DeactivateTeam(ctx context.Context, tx pgx.Tx, teamName string) ([]*domain.User, error) {
+func (r *UserRepo) DeactivateTeam(ctx context.Context, teamName string) ([]*domain.User, error) {
 	const op = "UserRepo.DeactivateTeam"
 
 	sql := `
 		UPDATE users SET is_active = FALSE WHERE team_name = $1
-		RETURNING id, name, team_name, is_active`
+		RETURNING id, name, team_name, is_active, role`
 
-	rows, err := tx.Query(ctx, sql, teamName)
+	rows, err := querier(ctx, r.pool).Query(ctx, sql, teamName)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -122,7 +150,7 @@ func (r *UserRepo) DeactivateTeam(ctx context.Context, tx pgx.Tx, teamName strin
 	for rows.Next() {
 		var u domain.User
 
-		if err = rows.Scan(&u.ID, &u.Name, &u.TeamName, &u.IsActive); err != nil {
+		if err = rows.Scan(&u.ID, &u.Name, &u.TeamName, &u.IsActive, &u.Role); err != nil {
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 
@@ -132,14 +160,14 @@ func (r *UserRepo) DeactivateTeam(ctx context.Context, tx pgx.Tx, teamName strin
 	return users, nil
 }
 
-func (r *UserRepo) UpsertUsers(ctx context.Context, tx pgx.Tx, users []*domain.User) error {
+func (r *UserRepo) UpsertUsers(ctx context.Context, users []*domain.User) error {
 	const op = "UserRepo.UpsertUsers"
 	
 	sql := `
-		INSERT INTO users (id, name, team_name, is_active)
+		INSERT INTO users (id, name, team_name, is_active, role)
 		VALUES %s
 		ON CONFLICT (id) DO UPDATE
-		SET name = EXCLUDED.name, team_name = EXCLUDED.team_name, is_active = EXCLUDED.is_active`
+		SET name = EXCLUDED.name, team_name = EXCLUDED.team_name, is_active = EXCLUDED.is_active, role = EXCLUDED.role`
 
 	values := ""
 	args := []any{}
@@ -150,14 +178,14 @@ func (r *UserRepo) UpsertUsers(ctx context.Context, tx pgx.Tx, users []*domain.U
 			comma = ""
 		}
 
-		idx := i * 4 + 1
-		values += fmt.Sprintf("($%d, $%d, $%d, $%d)%s ", idx, idx + 1, idx + 2, idx + 3, comma)
-		args = append(args, u.ID, u.Name, u.TeamName, u.IsActive)
+		idx := i * 5 + 1
+		values += fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)%s ", idx, idx + 1, idx + 2, idx + 3, idx + 4, comma)
+		args = append(args, u.ID, u.Name, u.TeamName, u.IsActive, string(u.Role))
 	}
 
 	sql = fmt.Sprintf(sql, values)
 
-	if _, err := tx.Exec(ctx, sql, args...); err != nil {
+	if _, err := querier(ctx, r.pool).Exec(ctx, sql, args...); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -3,20 +3,24 @@ package repository
 import (
 	"avito-task/internal/domain"
 	"context"
-
-	"github.com/jackc/pgx/v5"
 )
 
 type PullRequestRepo interface {
-	GetReviewers(ctx context.Context, tx pgx.Tx, prID string) ([]string, error)
+	GetReviewers(ctx context.Context, prID string) ([]string, error)
 	GetUserReviews(ctx context.Context, id string) ([]*domain.PullRequestShort, error)
-	AddReviewers(ctx context.Context, tx pgx.Tx, prID string, users []*domain.User) error
+	// GetUsersReviews is GetUserReviews for many users at once, keyed by user ID.
+	GetUsersReviews(ctx context.Context, ids []string) (map[string][]*domain.PullRequestShort, error)
+	AddReviewers(ctx context.Context, prID string, users []*domain.User) error
 
-	GetByID(ctx context.Context, tx pgx.Tx, id string) (*domain.PullRequest, error)
-	CreatePullRequest(ctx context.Context, tx pgx.Tx, pr *domain.PullRequest) (*domain.PullRequest, error)
-	Merge(ctx context.Context, tx pgx.Tx, id string) (*domain.PullRequest, error)
-	Reassign(ctx context.Context, tx pgx.Tx, prID string, prevID string, newID string) error
+	GetByID(ctx context.Context, id string) (*domain.PullRequest, error)
+	CreatePullRequest(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error)
+	// ImportPullRequest creates PR with given status and timestamps, CreatedAt defaults to now.
+	ImportPullRequest(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error)
+	Merge(ctx context.Context, id string) (*domain.PullRequest, error)
+	// Reassign replaces reviewer and returns the new version of the PR.
+	Reassign(ctx context.Context, prID string, prevID string, newID string) (int64, error)
 
-	GetUserReviewsCounts(ctx context.Context, tx pgx.Tx, teamName string) ([]*domain.UserStats, error)
-	GetPRReviewersCounts(ctx context.Context, tx pgx.Tx, teamName string) ([]*domain.PullRequestStats, error)
+	GetUserReviewsCounts(ctx context.Context, teamName string) ([]*domain.UserStats, error)
+	GetPRReviewersCounts(ctx context.Context, teamName string) ([]*domain.PullRequestStats, error)
+	GetTeamsReviewLoad(ctx context.Context, minReviewers int) ([]*domain.TeamReviewLoad, error)
 }
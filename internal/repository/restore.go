@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"avito-task/internal/domain"
+	"context"
+)
+
+// RestoreRepo writes rows of a backup as they are, keeping their keys and timestamps.
+type RestoreRepo interface {
+	// IsEmpty reports whether there are no teams, users, PRs and audit entries other than
+	// ones of API keys, which are written at startup when the bootstrap key is registered.
+	IsEmpty(ctx context.Context) (bool, error)
+	// LastAuditID returns the largest ID of audit entries or 0 if there are none.
+	LastAuditID(ctx context.Context) (int64, error)
+	Restore(ctx context.Context, rec *domain.ExportRecord) error
+	// SyncSequences moves generators of keys (audit entry IDs) past the restored rows.
+	SyncSequences(ctx context.Context) error
+}
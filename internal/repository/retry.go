@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"avito-task/pkg/logger"
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+)
+
+type RetryConfig struct {
+	MaxAttempts int           `yaml:"max_attempts" env:"TX_RETRY_MAX_ATTEMPTS" env-default:"5"`
+	BaseDelay   time.Duration `yaml:"base_delay" env-default:"10ms"`
+	MaxDelay    time.Duration `yaml:"max_delay" env-default:"200ms"`
+}
+
+type retryKey struct{}
+
+// RetriesExhaustedError is returned when all attempts of the transaction have failed.
+// It unwraps to ErrTxRetriesExhausted and keeps the error of the last attempt in Cause.
+type RetriesExhaustedError struct {
+	Attempts int
+	Cause    error
+}
+
+func (e *RetriesExhaustedError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Cause, ErrTxRetriesExhausted)
+}
+
+func (e *RetriesExhaustedError) Unwrap() error {
+	return ErrTxRetriesExhausted
+}
+
+// RetryTxManager runs transactions again when they are aborted because of concurrent ones
+// (serialization failures, deadlocks), waiting a random delay growing exponentially
+// between attempts.
+type RetryTxManager struct {
+	next      TxManager
+	cfg       RetryConfig
+	retryable func(err error) bool
+}
+
+func NewRetryTxManager(next TxManager, cfg RetryConfig, retryable func(err error) bool) *RetryTxManager {
+	return &RetryTxManager{
+		next:      next,
+		cfg:       cfg,
+		retryable: retryable,
+	}
+}
+
+// backoff returns the delay before the attempt following given one (counting from 1).
+func (m *RetryTxManager) backoff(attempt int) time.Duration {
+	delay := m.cfg.MaxDelay
+	if shift := attempt - 1; shift < 32 && m.cfg.BaseDelay<<shift < delay {
+		delay = m.cfg.BaseDelay << shift
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// WithinTx implements TxManager. Only the outermost call is retried, nested ones
+// join its transaction and fail together with it.
+func (m *RetryTxManager) WithinTx(ctx context.Context, opts TxOptions, fn func(ctx context.Context) error) error {
+	if ctx.Value(retryKey{}) != nil {
+		return m.next.WithinTx(ctx, opts, fn)
+	}
+
+	ctx = context.WithValue(ctx, retryKey{}, struct{}{})
+
+	for attempt := 1; ; attempt++ {
+		err := m.next.WithinTx(ctx, opts, fn)
+		if err == nil || !m.retryable(err) {
+			return err
+		}
+
+		if attempt >= m.cfg.MaxAttempts {
+			return &RetriesExhaustedError{Attempts: attempt, Cause: err}
+		}
+
+		delay := m.backoff(attempt)
+
+		slog.DebugContext(ctx, "retrying transaction",
+			slog.Int("attempt", attempt),
+			slog.Duration("delay", delay),
+			logger.Err(err),
+		)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
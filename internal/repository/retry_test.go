@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errConflict = errors.New("conflict")
+
+// countingTxManager runs fn without any transaction and counts outermost calls.
+type countingTxManager struct {
+	calls int
+}
+
+func (m *countingTxManager) WithinTx(ctx context.Context, _ TxOptions, fn func(ctx context.Context) error) error {
+	m.calls++
+	return fn(ctx)
+}
+
+func newTestRetryTxManager(next TxManager) *RetryTxManager {
+	return NewRetryTxManager(next, RetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    2 * time.Millisecond,
+	}, func(err error) bool {
+		return errors.Is(err, errConflict)
+	})
+}
+
+func TestRetryTxManager(t *testing.T) {
+	t.Run("retries until success", func(t *testing.T) {
+		next := &countingTxManager{}
+		m := newTestRetryTxManager(next)
+
+		err := m.WithinTx(context.Background(), TxOptions{}, func(context.Context) error {
+			if next.calls < 3 {
+				return errConflict
+			}
+
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, next.calls)
+	})
+
+	t.Run("exhausted attempts", func(t *testing.T) {
+		next := &countingTxManager{}
+		m := newTestRetryTxManager(next)
+
+		err := m.WithinTx(context.Background(), TxOptions{}, func(context.Context) error {
+			return errConflict
+		})
+
+		require.ErrorIs(t, err, ErrTxRetriesExhausted)
+		assert.Equal(t, 3, next.calls)
+
+		var exhausted *RetriesExhaustedError
+		require.ErrorAs(t, err, &exhausted)
+		assert.Equal(t, 3, exhausted.Attempts)
+		assert.ErrorIs(t, exhausted.Cause, errConflict)
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		next := &countingTxManager{}
+		m := newTestRetryTxManager(next)
+		errOther := errors.New("other")
+
+		err := m.WithinTx(context.Background(), TxOptions{}, func(context.Context) error {
+			return errOther
+		})
+
+		require.ErrorIs(t, err, errOther)
+		assert.Equal(t, 1, next.calls)
+	})
+
+	t.Run("nested calls are not retried", func(t *testing.T) {
+		next := &countingTxManager{}
+		m := newTestRetryTxManager(next)
+		nested := 0
+
+		err := m.WithinTx(context.Background(), TxOptions{}, func(ctx context.Context) error {
+			return m.WithinTx(ctx, TxOptions{}, func(context.Context) error {
+				nested++
+				return errConflict
+			})
+		})
+
+		require.ErrorIs(t, err, ErrTxRetriesExhausted)
+		assert.Equal(t, 3, nested)
+	})
+}
+
+func TestRetryTxManagerBackoff(t *testing.T) {
+	m := NewRetryTxManager(nil, RetryConfig{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}, nil)
+
+	for attempt, upper := range map[int]time.Duration{1: 10, 2: 20, 3: 40, 4: 50, 40: 50} {
+		delay := m.backoff(attempt)
+
+		assert.GreaterOrEqual(t, delay, upper*time.Millisecond/2)
+		assert.LessOrEqual(t, delay, upper*time.Millisecond)
+	}
+}
@@ -0,0 +1,25 @@
+package repository
+
+import "context"
+
+type IsoLevel string
+
+const (
+	ReadCommitted  IsoLevel = "read committed"
+	RepeatableRead IsoLevel = "repeatable read"
+	Serializable   IsoLevel = "serializable"
+)
+
+type TxOptions struct {
+	IsoLevel IsoLevel
+	ReadOnly bool
+}
+
+// TxManager runs functions in storage transactions. The transaction is carried by the
+// context passed to fn, and repositories of the same backend pick it up from there;
+// without a transaction in context they run each call on their own.
+type TxManager interface {
+	// WithinTx commits the transaction if fn returns nil and rolls it back otherwise.
+	// Nested calls join the transaction that is already in context, ignoring opts.
+	WithinTx(ctx context.Context, opts TxOptions, fn func(ctx context.Context) error) error
+}
@@ -3,8 +3,6 @@ package repository
 import (
 	"avito-task/internal/domain"
 	"context"
-
-	"github.com/jackc/pgx/v5"
 )
 
 type GetByTeamOpts struct {
@@ -15,9 +13,11 @@ type GetByTeamOpts struct {
 }
 
 type UserRepo interface {
-	GetByID(ctx context.Context, tx pgx.Tx, id string) (*domain.User, error)
-	GetByTeam(ctx context.Context, tx pgx.Tx, opts GetByTeamOpts) ([]*domain.User, error)
+	GetByID(ctx context.Context, id string) (*domain.User, error)
+	// GetByIDs returns existing users among ids in no particular order.
+	GetByIDs(ctx context.Context, ids []string) ([]*domain.User, error)
+	GetByTeam(ctx context.Context, opts GetByTeamOpts) ([]*domain.User, error)
 	SetIsActive(ctx context.Context, id string, isActive bool) (*domain.User, error)
-	DeactivateTeam(ctx context.Context, tx pgx.Tx, teamName string) ([]*domain.User, error)
-	UpsertUsers(ctx context.Context, tx pgx.Tx, users []*domain.User) error
+	DeactivateTeam(ctx context.Context, teamName string) ([]*domain.User, error)
+	UpsertUsers(ctx context.Context, users []*domain.User) error
 }
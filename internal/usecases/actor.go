@@ -0,0 +1,25 @@
+package usecases
+
+import (
+	"avito-task/internal/domain"
+	"context"
+)
+
+type actorKey struct{}
+
+func WithActor(ctx context.Context, actor *domain.Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns actor of the operation. Operations started without actor
+// (e.g. from the binary itself) are not restricted by team scope.
+func ActorFromContext(ctx context.Context) (*domain.Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(*domain.Actor)
+	return actor, ok && actor != nil
+}
+
+// CanManageTeam checks team scope of the context actor.
+func CanManageTeam(ctx context.Context, teamName string) bool {
+	actor, ok := ActorFromContext(ctx)
+	return !ok || actor.CanManageTeam(teamName)
+}
@@ -0,0 +1,10 @@
+package usecases
+
+import (
+	"avito-task/internal/domain"
+	"context"
+)
+
+type AuditService interface {
+	List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error)
+}
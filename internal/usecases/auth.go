@@ -0,0 +1,13 @@
+package usecases
+
+import (
+	"avito-task/internal/domain"
+	"context"
+)
+
+type AuthService interface {
+	Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, error)
+	IssueKey(ctx context.Context, key *domain.APIKey) (string, *domain.APIKey, error)
+	RevokeKey(ctx context.Context, id string) (*domain.APIKey, error)
+	BootstrapKey(ctx context.Context, rawKey string) error
+}
@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"avito-task/internal/domain"
+	"context"
+)
+
+type BatchOpType string
+
+const (
+	BatchCreatePR    BatchOpType = "create_pr"
+	BatchMergePR     BatchOpType = "merge_pr"
+	BatchReassign    BatchOpType = "reassign"
+	BatchSetIsActive BatchOpType = "set_is_active"
+)
+
+func (t BatchOpType) IsValid() bool {
+	switch t {
+	case BatchCreatePR, BatchMergePR, BatchReassign, BatchSetIsActive:
+		return true
+	}
+
+	return false
+}
+
+// BatchOperation is one operation of a batch, fields are used depending on Type.
+type BatchOperation struct {
+	Type BatchOpType
+
+	PR        *domain.PullRequest // create_pr
+	PRID      string              // merge_pr, reassign
+	UserID    string              // reassign (old reviewer), set_is_active
+	IsActive  bool                // set_is_active
+	IfVersion int64               // merge_pr, reassign
+}
+
+// BatchResult is the outcome of the operation with the same index, Err is nil on success.
+type BatchResult struct {
+	PR         *domain.PullRequest
+	User       *domain.User
+	ReplacedBy string
+	Err        error
+}
+
+type BatchService interface {
+	// Execute runs operations in order. Atomic batch runs in one transaction and stops at the
+	// first failed operation, the others get ErrBatchAborted; otherwise every operation is
+	// applied on its own. The error is returned only if the batch could not be run at all.
+	Execute(ctx context.Context, ops []*BatchOperation, atomic bool) ([]*BatchResult, error)
+}
@@ -9,4 +9,16 @@ var (
 	ErrPRMerged = errors.New("cannot reassign on merged PR")
 	ErrNotAssigned = errors.New("reviewer is not assigned to this PR")
 	ErrNoCandidate = errors.New("no active replacement candidate in team")
+	ErrPRVersionMismatch = errors.New("PR has been changed, If-Match does not match its current version")
+
+	ErrInvalidAPIKey = errors.New("invalid or revoked API key")
+	ErrTeamAccessDenied = errors.New("operation is allowed only within own team")
+
+	ErrIdempotencyKeyReused = errors.New("Idempotency-Key has already been used for another request")
+	ErrIdempotencyInProgress = errors.New("request with this Idempotency-Key is still being processed")
+
+	ErrBatchAborted = errors.New("operation is not applied: another operation of the atomic batch failed")
+
+	ErrBackupInvalid = errors.New("backup is invalid")
+	ErrRestoreNotEmpty = errors.New("storage is not empty, backup can be restored only into an empty one")
 )
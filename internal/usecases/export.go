@@ -0,0 +1,17 @@
+package usecases
+
+import (
+	"avito-task/internal/domain"
+	"context"
+)
+
+type ExportService interface {
+	// Export calls fn for rows of the entities in given order, all of them are read from one
+	// snapshot. Rows are streamed from storage, so fn must not keep the record after return.
+	Export(
+		ctx context.Context,
+		entities []domain.ExportEntity,
+		filter domain.ExportFilter,
+		fn func(rec *domain.ExportRecord) error,
+	) error
+}
@@ -0,0 +1,12 @@
+package usecases
+
+import (
+	"avito-task/internal/domain"
+	"context"
+)
+
+type IdempotencyService interface {
+	Begin(ctx context.Context, key domain.IdempotencyKey) (*domain.IdempotentResponse, error)
+	Complete(ctx context.Context, key domain.IdempotencyKey, resp *domain.IdempotentResponse) error
+	Abort(ctx context.Context, key domain.IdempotencyKey) error
+}
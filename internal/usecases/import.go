@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"avito-task/internal/domain"
+	"context"
+)
+
+// ImportProblem explains why a field of the import record can not be imported.
+type ImportProblem struct {
+	Field   string
+	Message string
+}
+
+// ImportRecord is one PR read from the import file.
+type ImportRecord struct {
+	// Line is the line of the file where the record starts.
+	Line int
+	PR   *domain.PullRequest
+	// HasReviewers is set if reviewers are listed explicitly (possibly none),
+	// otherwise they are assigned as for a new PR.
+	HasReviewers bool
+	// Problems found while reading the record, such records are not checked further.
+	Problems []ImportProblem
+}
+
+type ImportStatus string
+
+const (
+	// ImportStatusImported is the status of created PRs.
+	ImportStatusImported ImportStatus = "imported"
+	// ImportStatusValid is the status of records that would be imported, but the import
+	// is a dry run or has been rejected because of other records.
+	ImportStatusValid   ImportStatus = "valid"
+	ImportStatusInvalid ImportStatus = "invalid"
+)
+
+type ImportItem struct {
+	Line   int
+	Status ImportStatus
+	// PR is the imported (or to be imported) PR with its final reviewers.
+	PR *domain.PullRequest
+	// ReviewersAssigned is set if reviewers have been picked by the service.
+	ReviewersAssigned bool
+	Problems          []ImportProblem
+}
+
+type ImportReport struct {
+	DryRun bool
+	// Applied is set if PRs have been created, that is the import is not a dry run
+	// and all records are valid.
+	Applied bool
+	Valid   int
+	Invalid int
+	Items   []*ImportItem
+}
+
+type ImportService interface {
+	// ImportPullRequests creates PRs preserving their reviewers, status and timestamps.
+	// Records are checked against existing users and PRs, and PRs are created only if all
+	// of them are valid; a dry run only reports the result. The error is returned only
+	// if the import could not be run at all.
+	ImportPullRequests(ctx context.Context, records []*ImportRecord, dryRun bool) (*ImportReport, error)
+}
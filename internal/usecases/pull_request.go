@@ -7,6 +7,8 @@ import (
 
 type PullRequestService interface {
 	CreatePullRequest(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error)
-	Merge(ctx context.Context, id string) (*domain.PullRequest, error)
-	Reassign(ctx context.Context, prID string, userID string) (string, *domain.PullRequest, error)
+	GetPullRequest(ctx context.Context, id string) (*domain.PullRequest, error)
+	// Merge and Reassign fail with ErrPRVersionMismatch unless ifVersion is 0 or the current PR version.
+	Merge(ctx context.Context, id string, ifVersion int64) (*domain.PullRequest, error)
+	Reassign(ctx context.Context, prID string, userID string, ifVersion int64) (string, *domain.PullRequest, error)
 }
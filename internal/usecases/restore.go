@@ -0,0 +1,14 @@
+package usecases
+
+import (
+	"avito-task/internal/domain"
+	"context"
+)
+
+type RestoreService interface {
+	// Restore writes records returned by next until io.EOF into the empty storage in one
+	// transaction. Records must go in the order of domain.BackupEntities and may reference
+	// only the ones before them, otherwise ErrBackupInvalid is returned and nothing is restored.
+	// Counts of restored records by entity are returned.
+	Restore(ctx context.Context, next func() (*domain.ExportRecord, error)) (map[domain.ExportEntity]int, error)
+}
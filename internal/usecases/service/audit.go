@@ -0,0 +1,84 @@
+package service
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/repository"
+	"avito-task/internal/usecases"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"avito-task/pkg/requestid"
+)
+
+const systemActorID = "system"
+
+type AuditService struct {
+	auditRepo repository.AuditRepo
+}
+
+func NewAuditService(auditRepo repository.AuditRepo) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+	}
+}
+
+func (s *AuditService) List(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	const op = "AuditService.List"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	entries, err := s.auditRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return entries, nil
+}
+
+func marshalState(state any) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(state)
+}
+
+// writeAudit records mutating operation within the transaction carried by ctx, so the log entry
+// is committed (or rolled back) together with the change. Nil states are stored as NULL.
+func writeAudit(
+	ctx context.Context,
+	repo repository.AuditRepo,
+	action domain.AuditAction,
+	target domain.AuditTarget,
+	targetID string,
+	before any,
+	after any,
+) error {
+	entry := domain.AuditEntry{
+		ActorID:    systemActorID,
+		ActorRole:  systemActorID,
+		Action:     action,
+		TargetType: target,
+		TargetID:   targetID,
+		RequestID:  requestid.FromContext(ctx),
+	}
+
+	if actor, ok := usecases.ActorFromContext(ctx); ok {
+		entry.ActorID = actor.ID
+		entry.ActorRole = string(actor.Role)
+	}
+
+	var err error
+
+	if entry.Before, err = marshalState(before); err != nil {
+		return fmt.Errorf("failed to marshal audit state: %w", err)
+	}
+
+	if entry.After, err = marshalState(after); err != nil {
+		return fmt.Errorf("failed to marshal audit state: %w", err)
+	}
+
+	return repo.Write(ctx, &entry)
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/repository"
+	"avito-task/internal/usecases"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"avito-task/pkg/database"
+)
+
+const (
+	apiKeyPrefix = "avt_"
+	apiKeyBytes  = 32
+	keyIDBytes   = 8
+
+	bootstrapKeyPrefix = "bootstrap-"
+	bootstrapKeyName   = "bootstrap admin key"
+)
+
+type AuthService struct {
+	txManager repository.TxManager
+	keyRepo repository.APIKeyRepo
+	auditRepo repository.AuditRepo
+}
+
+func NewAuthService(
+	txManager repository.TxManager,
+	keyRepo repository.APIKeyRepo,
+	auditRepo repository.AuditRepo,
+) *AuthService {
+	return &AuthService{
+		txManager: txManager,
+		keyRepo: keyRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+func hashKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	_, _ = rand.Read(buf)
+
+	return hex.EncodeToString(buf)
+}
+
+func (s *AuthService) Authenticate(ctx context.Context, rawKey string) (*domain.APIKey, error) {
+	const op = "AuthService.Authenticate"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	key, err := s.keyRepo.GetActiveByHash(ctx, hashKey(rawKey))
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotExists) {
+			return nil, fmt.Errorf("%s: %w", op, usecases.ErrInvalidAPIKey)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return key, nil
+}
+
+func (s *AuthService) createKey(ctx context.Context, key *domain.APIKey, keyHash string) (*domain.APIKey, error) {
+	err := s.txManager.WithinTx(ctx, repository.TxOptions{
+		IsoLevel: repository.RepeatableRead,
+	}, func(ctx context.Context) error {
+		var err error
+
+		if key, err = s.keyRepo.Create(ctx, key, keyHash); err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, s.auditRepo, domain.AuditAPIKeyIssue, domain.AuditTargetAPIKey, key.ID, nil, key)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (s *AuthService) IssueKey(ctx context.Context, key *domain.APIKey) (string, *domain.APIKey, error) {
+	const op = "AuthService.IssueKey"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	rawKey := apiKeyPrefix + randomHex(apiKeyBytes)
+	key.ID = randomHex(keyIDBytes)
+
+	key, err := s.createKey(ctx, key, hashKey(rawKey))
+	if err != nil {
+		return "", nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rawKey, key, nil
+}
+
+func (s *AuthService) RevokeKey(ctx context.Context, id string) (*domain.APIKey, error) {
+	const op = "AuthService.RevokeKey"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	var key *domain.APIKey
+
+	err := s.txManager.WithinTx(ctx, repository.TxOptions{
+		IsoLevel: repository.RepeatableRead,
+	}, func(ctx context.Context) error {
+		var err error
+
+		if key, err = s.keyRepo.Revoke(ctx, id); err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, s.auditRepo, domain.AuditAPIKeyRevoke, domain.AuditTargetAPIKey, key.ID, nil, key)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return key, nil
+}
+
+// BootstrapKey registers admin key with given value unless it already exists,
+// so that the very first admin is able to issue other keys.
+func (s *AuthService) BootstrapKey(ctx context.Context, rawKey string) error {
+	const op = "AuthService.BootstrapKey"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	keyHash := hashKey(rawKey)
+
+	_, err := s.createKey(ctx, &domain.APIKey{
+		ID:   bootstrapKeyPrefix + keyHash[:keyIDBytes*2],
+		Name: bootstrapKeyName,
+		Role: domain.AccessAdmin,
+	}, keyHash)
+
+	if err != nil && !errors.Is(err, database.ErrUniqueViolation) {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
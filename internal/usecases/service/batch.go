@@ -0,0 +1,112 @@
+package service
+
+import (
+	"avito-task/internal/repository"
+	"avito-task/internal/usecases"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// errBatchOpFailed stops the transaction of atomic batch, the cause is kept in results.
+var errBatchOpFailed = errors.New("batch operation failed")
+
+type BatchService struct {
+	txManager repository.TxManager
+	prSvc usecases.PullRequestService
+	userSvc usecases.UserService
+}
+
+func NewBatchService(
+	txManager repository.TxManager,
+	prSvc usecases.PullRequestService,
+	userSvc usecases.UserService,
+) *BatchService {
+	return &BatchService{
+		txManager: txManager,
+		prSvc: prSvc,
+		userSvc: userSvc,
+	}
+}
+
+// apply runs single operation through the service it belongs to.
+func (s *BatchService) apply(ctx context.Context, op *usecases.BatchOperation) *usecases.BatchResult {
+	var res usecases.BatchResult
+
+	switch op.Type {
+	case usecases.BatchCreatePR:
+		res.PR, res.Err = s.prSvc.CreatePullRequest(ctx, op.PR)
+	case usecases.BatchMergePR:
+		res.PR, res.Err = s.prSvc.Merge(ctx, op.PRID, op.IfVersion)
+	case usecases.BatchReassign:
+		res.ReplacedBy, res.PR, res.Err = s.prSvc.Reassign(ctx, op.PRID, op.UserID, op.IfVersion)
+	case usecases.BatchSetIsActive:
+		res.User, res.Err = s.userSvc.SetIsActive(ctx, op.UserID, op.IsActive)
+	default:
+		res.Err = fmt.Errorf("unknown batch operation %q", op.Type)
+	}
+
+	return &res
+}
+
+func (s *BatchService) Execute(
+	ctx context.Context,
+	ops []*usecases.BatchOperation,
+	atomic bool,
+) ([]*usecases.BatchResult, error) {
+	const op = "BatchService.Execute"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	results := make([]*usecases.BatchResult, len(ops))
+
+	if !atomic {
+		for i, batchOp := range ops {
+			results[i] = s.apply(ctx, batchOp)
+		}
+
+		return results, nil
+	}
+
+	failed := -1
+
+	err := s.txManager.WithinTx(ctx, repository.TxOptions{
+		IsoLevel: repository.RepeatableRead,
+	}, func(ctx context.Context) error {
+		// The closure may be run again on conflicts, so results are collected from scratch.
+		failed = -1
+
+		for i, batchOp := range ops {
+			results[i] = s.apply(ctx, batchOp)
+
+			if results[i].Err != nil {
+				failed = i
+				return fmt.Errorf("%w: %w", errBatchOpFailed, results[i].Err)
+			}
+		}
+
+		return nil
+	})
+
+	// Conflicts exhausting retries and commit failures are not caused by any operation.
+	if err != nil && (failed < 0 || errors.Is(err, repository.ErrTxRetriesExhausted)) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if failed >= 0 {
+		for i := range results {
+			if i != failed {
+				results[i] = &usecases.BatchResult{Err: usecases.ErrBatchAborted}
+			}
+		}
+	}
+
+	slog.InfoContext(ctx, "atomic batch executed",
+		slog.Int("operations", len(ops)),
+		slog.Bool("committed", failed < 0),
+	)
+
+	return results, nil
+}
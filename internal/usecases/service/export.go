@@ -0,0 +1,63 @@
+package service
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/repository"
+	"context"
+	"fmt"
+)
+
+type ExportService struct {
+	txManager  repository.TxManager
+	teamRepo   repository.TeamRepo
+	exportRepo repository.ExportRepo
+}
+
+func NewExportService(
+	txManager repository.TxManager,
+	teamRepo repository.TeamRepo,
+	exportRepo repository.ExportRepo,
+) *ExportService {
+	return &ExportService{
+		txManager:  txManager,
+		teamRepo:   teamRepo,
+		exportRepo: exportRepo,
+	}
+}
+
+func (s *ExportService) Export(
+	ctx context.Context,
+	entities []domain.ExportEntity,
+	filter domain.ExportFilter,
+	fn func(rec *domain.ExportRecord) error,
+) error {
+	const op = "ExportService.Export"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	err := s.txManager.WithinTx(ctx, repository.TxOptions{
+		IsoLevel: repository.RepeatableRead,
+		ReadOnly: true,
+	}, func(ctx context.Context) error {
+		// Unknown team is reported before anything is written.
+		if filter.TeamName != "" {
+			if _, err := s.teamRepo.GetByName(ctx, filter.TeamName); err != nil {
+				return err
+			}
+		}
+
+		for _, entity := range entities {
+			if err := s.exportRepo.Export(ctx, entity, filter, fn); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/repository"
+	"avito-task/internal/usecases"
+	"context"
+	"fmt"
+	"time"
+)
+
+type IdempotencyService struct {
+	idemRepo repository.IdempotencyRepo
+	ttl      time.Duration
+	lease    time.Duration
+}
+
+// NewIdempotencyService creates service replaying responses for ttl after the first request.
+// The key is held for lease while the first request is processed, so a key of a request
+// lost with its process is released soon.
+func NewIdempotencyService(idemRepo repository.IdempotencyRepo, ttl, lease time.Duration) *IdempotencyService {
+	return &IdempotencyService{
+		idemRepo: idemRepo,
+		ttl:      ttl,
+		lease:    lease,
+	}
+}
+
+// Begin returns the response to replay if the request has already been processed,
+// or nil if it is a new one and the key is claimed for it.
+func (s *IdempotencyService) Begin(ctx context.Context, key domain.IdempotencyKey) (*domain.IdempotentResponse, error) {
+	const op = "IdempotencyService.Begin"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	rec, err := s.idemRepo.Reserve(ctx, key, s.lease)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	switch {
+	case rec == nil:
+		return nil, nil
+	case rec.Fingerprint != key.Fingerprint:
+		return nil, fmt.Errorf("%s: %w", op, usecases.ErrIdempotencyKeyReused)
+	case rec.Response == nil:
+		return nil, fmt.Errorf("%s: %w", op, usecases.ErrIdempotencyInProgress)
+	}
+
+	return rec.Response, nil
+}
+
+func (s *IdempotencyService) Complete(ctx context.Context, key domain.IdempotencyKey, resp *domain.IdempotentResponse) error {
+	const op = "IdempotencyService.Complete"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if err := s.idemRepo.Complete(ctx, key, resp, s.ttl); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *IdempotencyService) Abort(ctx context.Context, key domain.IdempotencyKey) error {
+	const op = "IdempotencyService.Abort"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	if err := s.idemRepo.Delete(ctx, key); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// PurgeExpired deletes keys that are no longer replayed.
+func (s *IdempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	const op = "IdempotencyService.PurgeExpired"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	deleted, err := s.idemRepo.DeleteExpired(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deleted, nil
+}
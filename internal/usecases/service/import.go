@@ -0,0 +1,245 @@
+package service
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/repository"
+	"avito-task/internal/usecases"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"avito-task/pkg/database"
+)
+
+// errImportRolledBack rolls back the transaction of dry run or rejected import.
+var errImportRolledBack = errors.New("import is rolled back")
+
+func (s *PullRequestService) ImportPullRequests(
+	ctx context.Context,
+	records []*usecases.ImportRecord,
+	dryRun bool,
+) (*usecases.ImportReport, error) {
+	const op = "PullRequestService.ImportPullRequests"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	var report *usecases.ImportReport
+
+	err := s.txManager.WithinTx(ctx, repository.TxOptions{
+		IsoLevel: repository.RepeatableRead,
+	}, func(ctx context.Context) error {
+		// The closure may be run again on conflicts, so the report is built from scratch.
+		report = &usecases.ImportReport{
+			DryRun: dryRun,
+			Items:  make([]*usecases.ImportItem, 0, len(records)),
+		}
+
+		lines := make(map[string]int, len(records))
+
+		for _, rec := range records {
+			item, err := s.importRecord(ctx, rec, lines)
+			if err != nil {
+				return err
+			}
+
+			if item.Status == usecases.ImportStatusInvalid {
+				report.Invalid++
+			} else {
+				report.Valid++
+			}
+
+			report.Items = append(report.Items, item)
+		}
+
+		if dryRun || report.Invalid > 0 {
+			return errImportRolledBack
+		}
+
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errImportRolledBack) {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	report.Applied = err == nil
+
+	for _, item := range report.Items {
+		if item.Status == usecases.ImportStatusValid && report.Applied {
+			item.Status = usecases.ImportStatusImported
+		}
+	}
+
+	slog.InfoContext(ctx, "pull requests import finished",
+		slog.Bool("dry_run", dryRun),
+		slog.Bool("applied", report.Applied),
+		slog.Int("valid", report.Valid),
+		slog.Int("invalid", report.Invalid),
+	)
+
+	return report, nil
+}
+
+// importRecord checks the record and creates its PR. Records are created even in a dry run,
+// so that the report shows assigned reviewers; the transaction is rolled back then.
+func (s *PullRequestService) importRecord(
+	ctx context.Context,
+	rec *usecases.ImportRecord,
+	lines map[string]int,
+) (*usecases.ImportItem, error) {
+	item := &usecases.ImportItem{
+		Line:     rec.Line,
+		Status:   usecases.ImportStatusInvalid,
+		PR:       rec.PR,
+		Problems: slices.Clone(rec.Problems),
+	}
+
+	if len(item.Problems) > 0 {
+		return item, nil
+	}
+
+	pr := *rec.PR
+	item.PR = &pr
+
+	if line, ok := lines[pr.ID]; ok {
+		item.Problems = append(item.Problems, usecases.ImportProblem{
+			Field:   "pull_request_id",
+			Message: fmt.Sprintf("duplicates the record on line %d", line),
+		})
+
+		return item, nil
+	}
+
+	lines[pr.ID] = rec.Line
+
+	author, reviewers, problems, err := s.checkImport(ctx, &pr, rec.HasReviewers)
+	if err != nil || len(problems) > 0 {
+		item.Problems = problems
+		return item, err
+	}
+
+	if !rec.HasReviewers && pr.Status == domain.PROpen {
+		team, err := s.teamRepo.GetByName(ctx, author.TeamName)
+		if err != nil {
+			return nil, err
+		}
+
+		reviewers, err = s.pickReviewers(ctx, team.Name, PickOpts{
+			Count:      maxReviewers,
+			ExcludeIDs: []string{author.ID},
+			Roles:      team.RequiredRoles,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		item.ReviewersAssigned = true
+	}
+
+	if _, err = s.prRepo.ImportPullRequest(ctx, &pr); err != nil {
+		if errors.Is(err, database.ErrUniqueViolation) {
+			return nil, usecases.ErrPRIDExists
+		}
+
+		return nil, err
+	}
+
+	if len(reviewers) > 0 {
+		if err = s.prRepo.AddReviewers(ctx, pr.ID, reviewers); err != nil {
+			return nil, err
+		}
+	}
+
+	pr.Reviewers = make([]string, 0, len(reviewers))
+	for _, r := range reviewers {
+		pr.Reviewers = append(pr.Reviewers, r.ID)
+	}
+
+	item.Status = usecases.ImportStatusValid
+
+	return item, writeAudit(ctx, s.auditRepo, domain.AuditPRImport, domain.AuditTargetPR, pr.ID, nil, &pr)
+}
+
+// checkImport validates the PR against existing data and returns its author and explicit reviewers.
+func (s *PullRequestService) checkImport(
+	ctx context.Context,
+	pr *domain.PullRequest,
+	hasReviewers bool,
+) (*domain.User, []*domain.User, []usecases.ImportProblem, error) {
+	var problems []usecases.ImportProblem
+
+	add := func(field, format string, args ...any) {
+		problems = append(problems, usecases.ImportProblem{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	_, err := s.prRepo.GetByID(ctx, pr.ID)
+	switch {
+	case err == nil:
+		add("pull_request_id", "PR already exists")
+	case !errors.Is(err, repository.ErrPRNotExists):
+		return nil, nil, nil, err
+	}
+
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	switch {
+	case errors.Is(err, repository.ErrUserNotExists):
+		add("author_id", "user %s does not exist", pr.AuthorID)
+	case err != nil:
+		return nil, nil, nil, err
+	}
+
+	now := time.Now()
+
+	switch {
+	case pr.CreatedAt != nil && pr.CreatedAt.After(now):
+		add("created_at", "must not be in the future")
+	case pr.MergedAt != nil && pr.Status != domain.PRMerged:
+		add("merged_at", "must be empty for %s PR", pr.Status)
+	case pr.MergedAt != nil && pr.MergedAt.After(now):
+		add("merged_at", "must not be in the future")
+	case pr.MergedAt != nil && pr.CreatedAt != nil && pr.MergedAt.Before(*pr.CreatedAt):
+		add("merged_at", "must not be before created_at")
+	}
+
+	if pr.Status == domain.PRMerged && pr.MergedAt == nil {
+		mergedAt := now.UTC()
+		pr.MergedAt = &mergedAt
+	}
+
+	if !hasReviewers {
+		return author, nil, problems, nil
+	}
+
+	if len(pr.Reviewers) > maxReviewers {
+		add("reviewers", "must contain at most %d reviewers", maxReviewers)
+	}
+
+	reviewers := make([]*domain.User, 0, len(pr.Reviewers))
+
+	for i, id := range pr.Reviewers {
+		switch {
+		case id == pr.AuthorID:
+			add("reviewers", "author %s can not be a reviewer", id)
+			continue
+		case slices.Index(pr.Reviewers, id) < i:
+			add("reviewers", "reviewer %s is listed twice", id)
+			continue
+		}
+
+		rew, err := s.userRepo.GetByID(ctx, id)
+		switch {
+		case errors.Is(err, repository.ErrUserNotExists):
+			add("reviewers", "user %s does not exist", id)
+		case err != nil:
+			return nil, nil, nil, err
+		default:
+			reviewers = append(reviewers, rew)
+		}
+	}
+
+	return author, reviewers, problems, nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"avito-task/internal/domain"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+)
+
+type PickOpts struct {
+	Count      int
+	ExcludeIDs []string
+	// Roles, if set, guarantee that one of the picked users has one of them
+	// (when such a candidate is available).
+	Roles []domain.UserRole
+}
+
+// ReviewerPicker selects random reviewers among candidates.
+// Randomness is taken from the injected source, so selection is reproducible for a fixed seed
+// and the same ordered list of candidates.
+type ReviewerPicker struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func NewReviewerPicker(src rand.Source) *ReviewerPicker {
+	return &ReviewerPicker{
+		rnd: rand.New(src), //nolint:gosec // reviewer selection is not security sensitive
+	}
+}
+
+// NewSeededReviewerPicker creates picker with PCG source seeded by seed.
+// Zero seed means that the seed is taken from current time.
+func NewSeededReviewerPicker(seed uint64) *ReviewerPicker {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano()) //nolint:gosec // time is always positive here
+	}
+
+	return NewReviewerPicker(rand.NewPCG(seed, seed))
+}
+
+// Pick returns up to opts.Count active candidates not listed in opts.ExcludeIDs.
+func (p *ReviewerPicker) Pick(candidates []*domain.User, opts PickOpts) []*domain.User {
+	pool := make([]*domain.User, 0, len(candidates))
+
+	for _, c := range candidates {
+		if c.IsActive && !slices.Contains(opts.ExcludeIDs, c.ID) {
+			pool = append(pool, c)
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	picked := make([]*domain.User, 0, opts.Count)
+
+	if len(opts.Roles) > 0 && opts.Count > 0 {
+		withRole := []int{}
+
+		for i, c := range pool {
+			if slices.Contains(opts.Roles, c.Role) {
+				withRole = append(withRole, i)
+			}
+		}
+
+		if len(withRole) > 0 {
+			i := withRole[p.rnd.IntN(len(withRole))]
+			picked = append(picked, pool[i])
+			pool = slices.Delete(pool, i, i+1)
+		}
+	}
+
+	for len(picked) < opts.Count && len(pool) > 0 {
+		i := p.rnd.IntN(len(pool))
+		picked = append(picked, pool[i])
+		pool = slices.Delete(pool, i, i+1)
+	}
+
+	return picked
+}
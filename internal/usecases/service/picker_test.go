@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/usecases/service"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func makeCandidates() []*domain.User {
+	return []*domain.User{
+		{ID: "u1", IsActive: true, Role: domain.RoleMiddle},
+		{ID: "u2", IsActive: true, Role: domain.RoleJunior},
+		{ID: "u3", IsActive: false, Role: domain.RoleSenior},
+		{ID: "u4", IsActive: true, Role: domain.RoleMiddle},
+		{ID: "u5", IsActive: true, Role: domain.RoleLead},
+	}
+}
+
+func pickedIDs(users []*domain.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+
+	return ids
+}
+
+func TestPickDeterministic(t *testing.T) {
+	first := service.NewSeededReviewerPicker(42)
+	second := service.NewSeededReviewerPicker(42)
+
+	for range 100 {
+		opts := service.PickOpts{Count: 2, ExcludeIDs: []string{"u1"}}
+		require.Equal(t,
+			pickedIDs(first.Pick(makeCandidates(), opts)),
+			pickedIDs(second.Pick(makeCandidates(), opts)),
+		)
+	}
+}
+
+func TestPickExclusion(t *testing.T) {
+	picker := service.NewReviewerPicker(rand.NewPCG(1, 2))
+
+	for range 1000 {
+		ids := pickedIDs(picker.Pick(makeCandidates(), service.PickOpts{
+			Count:      2,
+			ExcludeIDs: []string{"u1", "u5"},
+		}))
+
+		require.Len(t, ids, 2)
+		require.NotContains(t, ids, "u1")
+		require.NotContains(t, ids, "u5")
+		require.NotContains(t, ids, "u3", "inactive user must not be picked")
+		require.NotEqual(t, ids[0], ids[1])
+	}
+}
+
+func TestPickNotEnoughCandidates(t *testing.T) {
+	picker := service.NewReviewerPicker(rand.NewPCG(1, 2))
+
+	ids := pickedIDs(picker.Pick(makeCandidates(), service.PickOpts{
+		Count:      2,
+		ExcludeIDs: []string{"u1", "u2", "u4"},
+	}))
+	require.Equal(t, []string{"u5"}, ids)
+
+	ids = pickedIDs(picker.Pick(makeCandidates(), service.PickOpts{
+		Count:      2,
+		ExcludeIDs: []string{"u1", "u2", "u4", "u5"},
+	}))
+	require.Empty(t, ids)
+}
+
+func TestPickRequiredRole(t *testing.T) {
+	picker := service.NewReviewerPicker(rand.NewPCG(3, 4))
+	roles := []domain.UserRole{domain.RoleSenior, domain.RoleLead}
+
+	for range 1000 {
+		ids := pickedIDs(picker.Pick(makeCandidates(), service.PickOpts{Count: 2, Roles: roles}))
+		require.Len(t, ids, 2)
+		require.Contains(t, ids, "u5", "the only active lead must always be picked")
+	}
+
+	ids := pickedIDs(picker.Pick(makeCandidates(), service.PickOpts{
+		Count:      1,
+		ExcludeIDs: []string{"u5"},
+		Roles:      roles,
+	}))
+	require.Len(t, ids, 1, "fallback to any candidate when nobody has required role")
+}
+
+func TestPickFairness(t *testing.T) {
+	const iterations = 40000
+
+	picker := service.NewReviewerPicker(rand.NewPCG(5, 6))
+	counts := map[string]int{}
+
+	for range iterations {
+		for _, u := range picker.Pick(makeCandidates(), service.PickOpts{Count: 1}) {
+			counts[u.ID]++
+		}
+	}
+
+	active := []string{"u1", "u2", "u4", "u5"}
+	expected := float64(iterations) / float64(len(active))
+
+	require.Len(t, counts, len(active))
+
+	for _, id := range active {
+		require.InEpsilon(t, expected, float64(counts[id]), 0.05, "user %s picked %d times", id, counts[id])
+	}
+}
@@ -7,170 +7,331 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 
 	"avito-task/pkg/database"
-
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const maxReviewers = 2
+
 type PullRequestService struct {
-	pool *pgxpool.Pool
+	txManager repository.TxManager
 	prRepo repository.PullRequestRepo
 	userRepo repository.UserRepo
+	teamRepo repository.TeamRepo
+	auditRepo repository.AuditRepo
+	picker *ReviewerPicker
 }
 
 func NewPullRequestService(
-	pool *pgxpool.Pool,
+	txManager repository.TxManager,
 	prRepo repository.PullRequestRepo,
 	userRepo repository.UserRepo,
+	teamRepo repository.TeamRepo,
+	auditRepo repository.AuditRepo,
+	picker *ReviewerPicker,
 	) *PullRequestService {
 	return &PullRequestService{
-		pool: pool,
+		txManager: txManager,
 		prRepo: prRepo,
 		userRepo: userRepo,
+		teamRepo: teamRepo,
+		auditRepo: auditRepo,
+		picker: picker,
 	}
 }
 
-func (s *PullRequestService) CreatePullRequest(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
-	const op = "PullRequestService.CreatePullRequest"
-
-	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
-		IsoLevel: pgx.RepeatableRead,
+// pickReviewers selects up to count random active members of the team excluding given IDs.
+// If roles are set, one of the selected members is guaranteed to have one of them
+// (when such a member is available).
+func (s *PullRequestService) pickReviewers(
+	ctx context.Context,
+	teamName string,
+	opts PickOpts,
+) ([]*domain.User, error) {
+	candidates, err := s.userRepo.GetByTeam(ctx, repository.GetByTeamOpts{
+		TeamName: teamName,
+		OnlyActive: true,
 	})
-
 	if err != nil {
-		return nil, fmt.Errorf("%s: failed to begin tx: %w", op, err)
+		return nil, err
 	}
 
-	defer func() { _ = tx.Rollback(ctx) }()
+	return s.picker.Pick(candidates, opts), nil
+}
 
-	author, err := s.userRepo.GetByID(ctx, tx, pr.AuthorID)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+// needsRequiredRole reports whether the replacement of prev must have one of the team required roles,
+// i.e. prev is the only assigned reviewer satisfying the team rule.
+func (s *PullRequestService) needsRequiredRole(
+	ctx context.Context,
+	team *domain.Team,
+	prev *domain.User,
+	reviewers []string,
+) (bool, error) {
+	if len(team.RequiredRoles) == 0 || !team.HasRequiredRole(prev) {
+		return false, nil
 	}
 
-	pr, err = s.prRepo.CreatePullRequest(ctx, tx, pr)
-	if err != nil {
-		if errors.Is(err, database.ErrUniqueViolation) {
-			return nil, fmt.Errorf("%s: %w", op, usecases.ErrPRIDExists)
+	for _, id := range reviewers {
+		if id == prev.ID {
+			continue
 		}
 
-		return nil, fmt.Errorf("%s: %w", op, err)
+		rew, err := s.userRepo.GetByID(ctx, id)
+		if err != nil {
+			return false, err
+		}
+
+		if team.HasRequiredRole(rew) {
+			return false, nil
+		}
 	}
 
-	rews, err := s.userRepo.GetByTeam(ctx, tx, repository.GetByTeamOpts{
-		TeamName: author.TeamName,
-		OnlyActive: true,
-		Limit: 2,
-		ExcludeIDs: []string{author.ID},
+	return true, nil
+}
+
+func (s *PullRequestService) CreatePullRequest(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+	const op = "PullRequestService.CreatePullRequest"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	var result *domain.PullRequest
+
+	// The closure may be run again on conflicts, so the created PR is kept in its own
+	// variable and becomes the result only on success.
+	err := s.txManager.WithinTx(ctx, repository.TxOptions{
+		IsoLevel: repository.RepeatableRead,
+	}, func(ctx context.Context) error {
+		author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+
+		created, err := s.prRepo.CreatePullRequest(ctx, pr)
+		if err != nil {
+			if errors.Is(err, database.ErrUniqueViolation) {
+				return usecases.ErrPRIDExists
+			}
+
+			return err
+		}
+
+		team, err := s.teamRepo.GetByName(ctx, author.TeamName)
+		if err != nil {
+			return err
+		}
+
+		rews, err := s.pickReviewers(ctx, team.Name, PickOpts{
+			Count: maxReviewers,
+			ExcludeIDs: []string{author.ID},
+			Roles: team.RequiredRoles,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err = s.prRepo.AddReviewers(ctx, created.ID, rews); err != nil {
+			return err
+		}
+
+		// Repositories may return pr itself, so reviewers are collected from scratch.
+		created.Reviewers = nil
+
+		for _, r := range rews {
+			created.Reviewers = append(created.Reviewers, r.ID)
+		}
+
+		if err = writeAudit(ctx, s.auditRepo, domain.AuditPRCreate, domain.AuditTargetPR, created.ID, nil, created); err != nil {
+			return err
+		}
+
+		result = created
+
+		return nil
 	})
+
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err = s.prRepo.AddReviewers(ctx, tx, pr.ID, rews); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
+	slog.InfoContext(ctx, "pull request created",
+		slog.String("pull_request_id", result.ID),
+		slog.Any("reviewers", result.Reviewers),
+	)
 
-	for _, r := range rews {
-		pr.Reviewers = append(pr.Reviewers, r.ID)
-	}
+	return result, nil
+}
+
+func (s *PullRequestService) GetPullRequest(ctx context.Context, id string) (*domain.PullRequest, error) {
+	const op = "PullRequestService.GetPullRequest"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	var pr *domain.PullRequest
+
+	err := s.txManager.WithinTx(ctx, repository.TxOptions{
+		IsoLevel: repository.RepeatableRead,
+		ReadOnly: true,
+	}, func(ctx context.Context) error {
+		var err error
 
-	if err = tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("%s: failed to commit tx: %w", op, err)
+		if pr, err = s.prRepo.GetByID(ctx, id); err != nil {
+			return err
+		}
+
+		pr.Reviewers, err = s.prRepo.GetReviewers(ctx, id)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return pr, nil
 }
 
-func (s *PullRequestService) Merge(ctx context.Context, id string) (*domain.PullRequest, error) {
+func (s *PullRequestService) Merge(ctx context.Context, id string, ifVersion int64) (*domain.PullRequest, error) {
 	const op = "PullRequestService.Merge"
 
-	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
-		IsoLevel: pgx.RepeatableRead,
-	})
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
 
-	if err != nil {
-		return nil, fmt.Errorf("%s: failed to begin tx: %w", op, err)
-	}
+	var pr *domain.PullRequest
 
-	defer func() { _ = tx.Rollback(ctx) }()
+	err := s.txManager.WithinTx(ctx, repository.TxOptions{
+		IsoLevel: repository.RepeatableRead,
+	}, func(ctx context.Context) error {
+		before, err := s.prRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
 
-	pr, err := s.prRepo.Merge(ctx, tx, id)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
+		if ifVersion != 0 && before.Version != ifVersion {
+			return usecases.ErrPRVersionMismatch
+		}
+
+		if pr, err = s.prRepo.Merge(ctx, id); err != nil {
+			return err
+		}
+
+		if pr.Reviewers, err = s.prRepo.GetReviewers(ctx, pr.ID); err != nil {
+			return err
+		}
+
+		before.Reviewers = pr.Reviewers
+
+		return writeAudit(ctx, s.auditRepo, domain.AuditPRMerge, domain.AuditTargetPR, pr.ID, before, pr)
+	})
 
-	pr.Reviewers, err = s.prRepo.GetReviewers(ctx, tx, pr.ID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err = tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("%s: failed to commit tx: %w", op, err)
-	}
-
 	return pr, nil
 }
 
-func (s *PullRequestService) Reassign(ctx context.Context, prID string, userID string) (string, *domain.PullRequest, error) {
+func (s *PullRequestService) Reassign(
+	ctx context.Context,
+	prID string,
+	userID string,
+	ifVersion int64,
+) (string, *domain.PullRequest, error) {
 	const op = "PullRequestService.Reassign"
 
-	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
-		IsoLevel: pgx.RepeatableRead,
-	})
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
 
-	if err != nil {
-		return "", nil, fmt.Errorf("%s: failed to begin tx: %w", op, err)
-	}
+	var (
+		pr    *domain.PullRequest
+		prev  *domain.User
+		newID string
+	)
 
-	defer func() { _ = tx.Rollback(ctx) }()
+	err := s.txManager.WithinTx(ctx, repository.TxOptions{
+		IsoLevel: repository.RepeatableRead,
+	}, func(ctx context.Context) error {
+		var err error
 
-	prev, err := s.userRepo.GetByID(ctx, tx, userID)
-	if err != nil {
-		return "", nil, fmt.Errorf("%s: %w", op, err)
-	}
+		if prev, err = s.userRepo.GetByID(ctx, userID); err != nil {
+			return err
+		}
 
-	pr, err := s.prRepo.GetByID(ctx, tx, prID)
-	if err != nil {
-		return "", nil, fmt.Errorf("%s: %w", op, err)
-	}
+		if pr, err = s.prRepo.GetByID(ctx, prID); err != nil {
+			return err
+		}
 
-	if pr.Status == domain.PRMerged {
-		return "", nil, fmt.Errorf("%s: %w", op, usecases.ErrPRMerged)
-	}
+		if ifVersion != 0 && pr.Version != ifVersion {
+			return usecases.ErrPRVersionMismatch
+		}
 
-	rews, err := s.userRepo.GetByTeam(ctx, tx, repository.GetByTeamOpts{
-		TeamName: prev.TeamName,
-		OnlyActive: true,
-		Limit: 1,
-		ExcludeIDs: []string{prev.ID, pr.AuthorID},
-	})
-	if err != nil {
-		return "", nil, fmt.Errorf("%s: %w", op, err)
-	}
+		if pr.Status == domain.PRMerged {
+			return usecases.ErrPRMerged
+		}
 
-	if len(rews) == 0 {
-		return "", nil, fmt.Errorf("%s: %w", op, usecases.ErrNoCandidate)
-	}
+		team, err := s.teamRepo.GetByName(ctx, prev.TeamName)
+		if err != nil {
+			return err
+		}
 
-	if err = s.prRepo.Reassign(ctx, tx, pr.ID, prev.ID, rews[0].ID); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return "", nil, fmt.Errorf("%s: %w", op, usecases.ErrNotAssigned)
+		curRews, err := s.prRepo.GetReviewers(ctx, pr.ID)
+		if err != nil {
+			return err
 		}
 
-		return "", nil, fmt.Errorf("%s: %w", op, err)
-	}
+		needRole, err := s.needsRequiredRole(ctx, team, prev, curRews)
+		if err != nil {
+			return err
+		}
+
+		var roles []domain.UserRole
+		if needRole {
+			roles = team.RequiredRoles
+		}
+
+		rews, err := s.pickReviewers(ctx, team.Name, PickOpts{
+			Count: 1,
+			ExcludeIDs: append(curRews, prev.ID, pr.AuthorID),
+			Roles: roles,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(rews) == 0 {
+			return usecases.ErrNoCandidate
+		}
+
+		newID = rews[0].ID
+
+		before := *pr
+		before.Reviewers = curRews
+
+		if pr.Version, err = s.prRepo.Reassign(ctx, pr.ID, prev.ID, newID); err != nil {
+			if errors.Is(err, repository.ErrReviewerNotAssigned) {
+				return usecases.ErrNotAssigned
+			}
+
+			return err
+		}
+
+		if pr.Reviewers, err = s.prRepo.GetReviewers(ctx, pr.ID); err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, s.auditRepo, domain.AuditPRReassign, domain.AuditTargetPR, pr.ID, &before, pr)
+	})
 
-	pr.Reviewers, err = s.prRepo.GetReviewers(ctx, tx, pr.ID)
 	if err != nil {
 		return "", nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err = tx.Commit(ctx); err != nil {
-		return "", nil, fmt.Errorf("%s: failed to commit tx: %w", op, err)
-	}
+	slog.InfoContext(ctx, "reviewer reassigned",
+		slog.String("pull_request_id", pr.ID),
+		slog.String("old_reviewer_id", prev.ID),
+		slog.String("new_reviewer_id", newID),
+	)
 
-	return rews[0].ID, pr, nil
+	return newID, pr, nil
 }
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"avito-task/internal/domain"
+	"avito-task/internal/repository"
+	"avito-task/internal/usecases"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+)
+
+// errRestoreRetried is returned if the transaction is run again after a conflict: records
+// have been consumed by the first attempt, so the restore has to be started anew.
+var errRestoreRetried = errors.New("restore conflicted with a concurrent transaction, run it again")
+
+type RestoreService struct {
+	txManager   repository.TxManager
+	restoreRepo repository.RestoreRepo
+}
+
+func NewRestoreService(txManager repository.TxManager, restoreRepo repository.RestoreRepo) *RestoreService {
+	return &RestoreService{
+		txManager:   txManager,
+		restoreRepo: restoreRepo,
+	}
+}
+
+func (s *RestoreService) Restore(
+	ctx context.Context,
+	next func() (*domain.ExportRecord, error),
+) (map[domain.ExportEntity]int, error) {
+	const op = "RestoreService.Restore"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	counts := make(map[domain.ExportEntity]int, len(domain.BackupEntities))
+	attempts := 0
+
+	err := s.txManager.WithinTx(ctx, repository.TxOptions{
+		IsoLevel: repository.Serializable,
+	}, func(ctx context.Context) error {
+		if attempts++; attempts > 1 {
+			return errRestoreRetried
+		}
+
+		empty, err := s.restoreRepo.IsEmpty(ctx)
+		if err != nil {
+			return err
+		}
+
+		if !empty {
+			return usecases.ErrRestoreNotEmpty
+		}
+
+		// Entries of API keys registered at startup stay first, restored ones follow them.
+		auditOffset, err := s.restoreRepo.LastAuditID(ctx)
+		if err != nil {
+			return err
+		}
+
+		keys := newRestoredKeys()
+
+		for {
+			rec, err := next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if err = keys.add(rec); err != nil {
+				return err
+			}
+
+			if rec.Entity == domain.ExportAudit && auditOffset > 0 {
+				shifted, entry := *rec, *rec.Audit
+				entry.ID += auditOffset
+				shifted.Audit = &entry
+				rec = &shifted
+			}
+
+			if err = s.restoreRepo.Restore(ctx, rec); err != nil {
+				return err
+			}
+
+			counts[rec.Entity]++
+		}
+
+		return s.restoreRepo.SyncSequences(ctx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	slog.InfoContext(ctx, "backup restored", slog.Any("counts", counts))
+
+	return counts, nil
+}
+
+// restoredKeys keeps keys of restored records to check references of the following ones.
+type restoredKeys struct {
+	// entity is the index of the current entity in domain.BackupEntities.
+	entity  int
+	teams   map[string]struct{}
+	users   map[string]struct{}
+	prs     map[string]struct{}
+	reviews map[domain.Review]struct{}
+	auditID int64
+}
+
+func newRestoredKeys() *restoredKeys {
+	return &restoredKeys{
+		teams:   make(map[string]struct{}),
+		users:   make(map[string]struct{}),
+		prs:     make(map[string]struct{}),
+		reviews: make(map[domain.Review]struct{}),
+	}
+}
+
+// add checks the record against the ones restored before it and remembers its key.
+func (k *restoredKeys) add(rec *domain.ExportRecord) error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", usecases.ErrBackupInvalid, fmt.Sprintf(format, args...))
+	}
+
+	idx := slices.Index(domain.BackupEntities, rec.Entity)
+	switch {
+	case idx < 0:
+		return invalid("unknown entity %q", rec.Entity)
+	case idx < k.entity:
+		return invalid("%s must go before %s", rec.Entity, domain.BackupEntities[k.entity])
+	}
+
+	k.entity = idx
+
+	switch rec.Entity {
+	case domain.ExportTeams:
+		t := rec.Team
+
+		if _, ok := k.teams[t.Name]; ok || t.Name == "" {
+			return invalid("team %q is empty or repeated", t.Name)
+		}
+
+		for _, role := range t.RequiredRoles {
+			if !role.IsValid() {
+				return invalid("team %s requires unknown role %q", t.Name, role)
+			}
+		}
+
+		k.teams[t.Name] = struct{}{}
+	case domain.ExportUsers:
+		u := rec.User
+
+		if _, ok := k.users[u.ID]; ok || u.ID == "" {
+			return invalid("user %q is empty or repeated", u.ID)
+		}
+
+		if _, ok := k.teams[u.TeamName]; !ok && u.TeamName != "" {
+			return invalid("user %s references missing team %s", u.ID, u.TeamName)
+		}
+
+		if !u.Role.IsValid() {
+			return invalid("user %s has unknown role %q", u.ID, u.Role)
+		}
+
+		k.users[u.ID] = struct{}{}
+	case domain.ExportPullRequests:
+		pr := rec.PR
+
+		if _, ok := k.prs[pr.ID]; ok || pr.ID == "" {
+			return invalid("PR %q is empty or repeated", pr.ID)
+		}
+
+		if _, ok := k.users[pr.AuthorID]; !ok {
+			return invalid("PR %s references missing author %s", pr.ID, pr.AuthorID)
+		}
+
+		if pr.Status != domain.PROpen && pr.Status != domain.PRMerged {
+			return invalid("PR %s has unknown status %q", pr.ID, pr.Status)
+		}
+
+		if pr.Version < 1 {
+			return invalid("PR %s has version %d, expected at least 1", pr.ID, pr.Version)
+		}
+
+		k.prs[pr.ID] = struct{}{}
+	case domain.ExportReviews:
+		rv := *rec.Review
+
+		if _, ok := k.prs[rv.PullRequestID]; !ok {
+			return invalid("review references missing PR %s", rv.PullRequestID)
+		}
+
+		if _, ok := k.users[rv.UserID]; !ok {
+			return invalid("review of PR %s references missing user %s", rv.PullRequestID, rv.UserID)
+		}
+
+		if _, ok := k.reviews[rv]; ok {
+			return invalid("reviewer %s of PR %s is repeated", rv.UserID, rv.PullRequestID)
+		}
+
+		k.reviews[rv] = struct{}{}
+	case domain.ExportAudit:
+		e := rec.Audit
+
+		if e.ID <= k.auditID {
+			return invalid("audit entry %d must go after %d", e.ID, k.auditID)
+		}
+
+		if e.CreatedAt == nil {
+			return invalid("audit entry %d has no creation time", e.ID)
+		}
+
+		k.auditID = e.ID
+	}
+
+	return nil
+}
@@ -0,0 +1,579 @@
+package service_test
+
+import (
+	"avito-task/internal/backup"
+	"avito-task/internal/domain"
+	"avito-task/internal/repository"
+	"avito-task/internal/repository/memory"
+	"avito-task/internal/usecases"
+	"avito-task/internal/usecases/service"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type services struct {
+	team   *service.TeamService
+	user   *service.UserService
+	pr     *service.PullRequestService
+	batch  *service.BatchService
+	export *service.ExportService
+	prRepo repository.PullRequestRepo
+}
+
+// newServices wires services on top of in-memory storage.
+func newServices() *services {
+	store := memory.NewStore()
+	teamRepo := memory.NewTeamRepo(store)
+	userRepo := memory.NewUserRepo(store)
+	prRepo := memory.NewPullRequestRepo(store)
+	auditRepo := memory.NewAuditRepo(store)
+
+	svc := &services{
+		team:   service.NewTeamService(store, teamRepo, userRepo, prRepo, auditRepo),
+		user:   service.NewUserService(store, userRepo, prRepo, auditRepo),
+		pr:     service.NewPullRequestService(store, prRepo, userRepo, teamRepo, auditRepo, service.NewSeededReviewerPicker(7)),
+		export: service.NewExportService(store, teamRepo, memory.NewExportRepo(store)),
+		prRepo: prRepo,
+	}
+	svc.batch = service.NewBatchService(store, svc.pr, svc.user)
+
+	return svc
+}
+
+func createTeam(t *testing.T, svc *services, name string, ids ...string) {
+	t.Helper()
+
+	team := &domain.Team{Name: name}
+	for _, id := range ids {
+		team.Members = append(team.Members, &domain.User{ID: id, Name: id, IsActive: true, Role: domain.RoleMiddle})
+	}
+
+	_, err := svc.team.CreateTeam(context.Background(), team)
+	require.NoError(t, err)
+}
+
+func TestPullRequestLifecycle(t *testing.T) {
+	ctx := context.Background()
+	svc := newServices()
+	createTeam(t, svc, "backend", "u1", "u2", "u3", "u4")
+
+	pr, err := svc.pr.CreatePullRequest(ctx, &domain.PullRequest{ID: "pr-1", Name: "feature", AuthorID: "u1"})
+	require.NoError(t, err)
+	require.Equal(t, domain.PROpen, pr.Status)
+	require.Len(t, pr.Reviewers, 2)
+	require.NotContains(t, pr.Reviewers, "u1")
+
+	_, err = svc.pr.CreatePullRequest(ctx, &domain.PullRequest{ID: "pr-1", Name: "again", AuthorID: "u1"})
+	require.ErrorIs(t, err, usecases.ErrPRIDExists)
+
+	old := pr.Reviewers[0]
+	newID, pr, err := svc.pr.Reassign(ctx, "pr-1", old, 0)
+	require.NoError(t, err)
+	require.NotEqual(t, old, newID)
+	require.NotContains(t, pr.Reviewers, old)
+	require.NotContains(t, pr.Reviewers, "u1")
+	require.Contains(t, pr.Reviewers, newID)
+
+	_, _, err = svc.pr.Reassign(ctx, "pr-1", "u1", 0)
+	require.ErrorIs(t, err, usecases.ErrNotAssigned)
+
+	merged, err := svc.pr.Merge(ctx, "pr-1", 0)
+	require.NoError(t, err)
+	require.Equal(t, domain.PRMerged, merged.Status)
+	require.NotNil(t, merged.MergedAt)
+
+	again, err := svc.pr.Merge(ctx, "pr-1", 0)
+	require.NoError(t, err)
+	require.Equal(t, merged.MergedAt, again.MergedAt)
+
+	_, _, err = svc.pr.Reassign(ctx, "pr-1", newID, 0)
+	require.ErrorIs(t, err, usecases.ErrPRMerged)
+}
+
+var errConflict = errors.New("conflict")
+
+// conflictingPRRepo fails the first creation of PR the way the database aborts conflicting transactions.
+type conflictingPRRepo struct {
+	repository.PullRequestRepo
+	failed bool
+}
+
+func (r *conflictingPRRepo) CreatePullRequest(ctx context.Context, pr *domain.PullRequest) (*domain.PullRequest, error) {
+	if !r.failed {
+		r.failed = true
+		return nil, errConflict
+	}
+
+	return r.PullRequestRepo.CreatePullRequest(ctx, pr)
+}
+
+func TestCreatePullRequestRetried(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	teamRepo := memory.NewTeamRepo(store)
+	userRepo := memory.NewUserRepo(store)
+	prRepo := &conflictingPRRepo{PullRequestRepo: memory.NewPullRequestRepo(store)}
+	auditRepo := memory.NewAuditRepo(store)
+
+	txManager := repository.NewRetryTxManager(store, repository.RetryConfig{MaxAttempts: 2}, func(err error) bool {
+		return errors.Is(err, errConflict)
+	})
+
+	_, err := service.NewTeamService(store, teamRepo, userRepo, prRepo, auditRepo).CreateTeam(ctx, &domain.Team{
+		Name: "backend",
+		Members: []*domain.User{
+			{ID: "u1", Name: "u1", IsActive: true, Role: domain.RoleMiddle},
+			{ID: "u2", Name: "u2", IsActive: true, Role: domain.RoleMiddle},
+		},
+	})
+	require.NoError(t, err)
+
+	prSvc := service.NewPullRequestService(txManager, prRepo, userRepo, teamRepo, auditRepo, service.NewSeededReviewerPicker(7))
+
+	pr, err := prSvc.CreatePullRequest(ctx, &domain.PullRequest{ID: "pr-1", Name: "feature", AuthorID: "u1"})
+	require.NoError(t, err)
+	require.True(t, prRepo.failed)
+	require.Equal(t, "pr-1", pr.ID)
+	require.Equal(t, []string{"u2"}, pr.Reviewers)
+}
+
+func TestCreateTeamRollback(t *testing.T) {
+	ctx := context.Background()
+	svc := newServices()
+	createTeam(t, svc, "backend", "u1", "u2")
+
+	_, err := svc.team.CreateTeam(ctx, &domain.Team{Name: "backend"})
+	require.ErrorIs(t, err, usecases.ErrTeamNameExists)
+
+	// The failed call must not leave anything behind and must release the storage.
+	team, err := svc.team.GetTeam(ctx, "backend")
+	require.NoError(t, err)
+	require.Len(t, team.Members, 2)
+}
+
+func TestRollbackRevertsChanges(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	teamRepo := memory.NewTeamRepo(store)
+	userRepo := memory.NewUserRepo(store)
+	auditRepo := memory.NewAuditRepo(store)
+	errFailed := errors.New("failed")
+
+	write := func(ctx context.Context, name string, isActive bool) error {
+		if _, err := teamRepo.CreateTeam(ctx, &domain.Team{Name: name}); err != nil {
+			return err
+		}
+
+		if err := userRepo.UpsertUsers(ctx, []*domain.User{{ID: "u1", Name: "u1", TeamName: name, IsActive: isActive}}); err != nil {
+			return err
+		}
+
+		return auditRepo.Write(ctx, &domain.AuditEntry{Action: domain.AuditTeamCreate, TargetType: domain.AuditTargetTeam, TargetID: name})
+	}
+
+	require.NoError(t, store.WithinTx(ctx, repository.TxOptions{}, func(ctx context.Context) error {
+		return write(ctx, "backend", true)
+	}))
+
+	err := store.WithinTx(ctx, repository.TxOptions{}, func(ctx context.Context) error {
+		if err := write(ctx, "frontend", false); err != nil {
+			return err
+		}
+
+		return errFailed
+	})
+	require.ErrorIs(t, err, errFailed)
+
+	_, err = teamRepo.GetByName(ctx, "frontend")
+	require.ErrorIs(t, err, repository.ErrTeamNotExists)
+
+	u, err := userRepo.GetByID(ctx, "u1")
+	require.NoError(t, err)
+	require.Equal(t, "backend", u.TeamName)
+	require.True(t, u.IsActive)
+
+	entries, err := auditRepo.List(ctx, domain.AuditFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	// IDs of rolled back entries are given out again.
+	require.NoError(t, auditRepo.Write(ctx, &domain.AuditEntry{Action: domain.AuditTeamCreate, TargetType: domain.AuditTargetTeam, TargetID: "qa"}))
+
+	entries, err = auditRepo.List(ctx, domain.AuditFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.ElementsMatch(t, []int64{1, 2}, []int64{entries[0].ID, entries[1].ID})
+}
+
+func TestDeactivatedUsersAreNotAssigned(t *testing.T) {
+	ctx := context.Background()
+	svc := newServices()
+	createTeam(t, svc, "backend", "u1", "u2", "u3")
+
+	_, err := svc.user.SetIsActive(ctx, "u2", false)
+	require.NoError(t, err)
+
+	pr, err := svc.pr.CreatePullRequest(ctx, &domain.PullRequest{ID: "pr-1", Name: "feature", AuthorID: "u1"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"u3"}, pr.Reviewers)
+
+	_, _, err = svc.pr.Reassign(ctx, "pr-1", "u3", 0)
+	require.ErrorIs(t, err, usecases.ErrNoCandidate)
+
+	reviews, err := svc.user.GetReview(ctx, "u3")
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	require.Equal(t, "pr-1", reviews[0].ID)
+
+	load, err := svc.prRepo.GetTeamsReviewLoad(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, []*domain.TeamReviewLoad{
+		{TeamName: "backend", OpenPRs: 1, OpenReviews: 1, UnderstaffedPRs: 1},
+	}, load)
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+	svc := service.NewIdempotencyService(memory.NewIdempotencyRepo(memory.NewStore()), time.Hour, 20*time.Millisecond)
+	key := domain.IdempotencyKey{Scope: "client", Key: "k1", Fingerprint: "create pr-1"}
+
+	resp, err := svc.Begin(ctx, key)
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	_, err = svc.Begin(ctx, key)
+	require.ErrorIs(t, err, usecases.ErrIdempotencyInProgress)
+
+	stored := &domain.IdempotentResponse{
+		StatusCode: 201,
+		Headers:    map[string]string{"Content-Type": "application/json", "ETag": `"1"`},
+		Body:       []byte(`{}`),
+	}
+	require.NoError(t, svc.Complete(ctx, key, stored))
+
+	// The response is kept for ttl, not for the lease of the request.
+	time.Sleep(30 * time.Millisecond)
+
+	resp, err = svc.Begin(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, stored, resp)
+
+	_, err = svc.Begin(ctx, domain.IdempotencyKey{Scope: "client", Key: "k1", Fingerprint: "create pr-2"})
+	require.ErrorIs(t, err, usecases.ErrIdempotencyKeyReused)
+
+	// The same key of another client is independent.
+	resp, err = svc.Begin(ctx, domain.IdempotencyKey{Scope: "other", Key: "k1", Fingerprint: "create pr-2"})
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	// The key of a request that has never completed is released when its lease expires.
+	time.Sleep(30 * time.Millisecond)
+
+	resp, err = svc.Begin(ctx, domain.IdempotencyKey{Scope: "other", Key: "k1", Fingerprint: "create pr-2"})
+	require.NoError(t, err)
+	require.Nil(t, resp)
+}
+
+func TestPullRequestVersions(t *testing.T) {
+	ctx := context.Background()
+	svc := newServices()
+	createTeam(t, svc, "backend", "u1", "u2", "u3", "u4")
+
+	pr, err := svc.pr.CreatePullRequest(ctx, &domain.PullRequest{ID: "pr-1", Name: "feature", AuthorID: "u1"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), pr.Version)
+
+	_, pr, err = svc.pr.Reassign(ctx, "pr-1", pr.Reviewers[0], 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), pr.Version)
+
+	// The second lead still holds the version before reassignment.
+	_, _, err = svc.pr.Reassign(ctx, "pr-1", pr.Reviewers[0], 1)
+	require.ErrorIs(t, err, usecases.ErrPRVersionMismatch)
+
+	_, err = svc.pr.Merge(ctx, "pr-1", 1)
+	require.ErrorIs(t, err, usecases.ErrPRVersionMismatch)
+
+	merged, err := svc.pr.Merge(ctx, "pr-1", 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), merged.Version)
+
+	again, err := svc.pr.Merge(ctx, "pr-1", 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), again.Version)
+}
+
+func TestBatch(t *testing.T) {
+	ctx := context.Background()
+	svc := newServices()
+	createTeam(t, svc, "backend", "u1", "u2", "u3")
+
+	ops := []*usecases.BatchOperation{
+		{Type: usecases.BatchCreatePR, PR: &domain.PullRequest{ID: "pr-1", Name: "feature", AuthorID: "u1"}},
+		{Type: usecases.BatchSetIsActive, UserID: "u2", IsActive: false},
+		{Type: usecases.BatchMergePR, PRID: "pr-2"},
+	}
+
+	results, err := svc.batch.Execute(ctx, ops, true)
+	require.NoError(t, err)
+	require.ErrorIs(t, results[0].Err, usecases.ErrBatchAborted)
+	require.ErrorIs(t, results[1].Err, usecases.ErrBatchAborted)
+	require.ErrorIs(t, results[2].Err, repository.ErrPRNotExists)
+
+	// Nothing of the failed atomic batch is applied.
+	_, err = svc.pr.GetPullRequest(ctx, "pr-1")
+	require.ErrorIs(t, err, repository.ErrPRNotExists)
+
+	users, err := svc.user.GetUsers(ctx, []string{"u2"})
+	require.NoError(t, err)
+	require.True(t, users[0].IsActive)
+
+	results, err = svc.batch.Execute(ctx, ops, false)
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+	require.Equal(t, "pr-1", results[0].PR.ID)
+	require.NoError(t, results[1].Err)
+	require.False(t, results[1].User.IsActive)
+	require.ErrorIs(t, results[2].Err, repository.ErrPRNotExists)
+}
+
+func TestImportPullRequests(t *testing.T) {
+	ctx := context.Background()
+	svc := newServices()
+	createTeam(t, svc, "backend", "u1", "u2", "u3")
+
+	createdAt := time.Date(2025, 9, 1, 10, 0, 0, 0, time.UTC)
+	mergedAt := createdAt.Add(time.Hour)
+
+	records := []*usecases.ImportRecord{
+		{Line: 2, PR: &domain.PullRequest{ID: "pr-1", Name: "old", AuthorID: "u1", Status: domain.PRMerged,
+			CreatedAt: &createdAt, MergedAt: &mergedAt, Reviewers: []string{"u3"}}, HasReviewers: true},
+		{Line: 3, PR: &domain.PullRequest{ID: "pr-2", Name: "new", AuthorID: "u1", Status: domain.PROpen}},
+		{Line: 4, PR: &domain.PullRequest{ID: "pr-3", Name: "bad", AuthorID: "u9", Status: domain.PROpen,
+			Reviewers: []string{"u9"}}, HasReviewers: true},
+	}
+
+	report, err := svc.pr.ImportPullRequests(ctx, records, false)
+	require.NoError(t, err)
+	require.False(t, report.Applied)
+	require.Equal(t, 2, report.Valid)
+	require.Equal(t, usecases.ImportStatusValid, report.Items[0].Status)
+	require.Equal(t, []usecases.ImportProblem{
+		{Field: "author_id", Message: "user u9 does not exist"},
+		{Field: "reviewers", Message: "author u9 can not be a reviewer"},
+	}, report.Items[2].Problems)
+
+	// Rejected import leaves nothing behind.
+	_, err = svc.pr.GetPullRequest(ctx, "pr-1")
+	require.ErrorIs(t, err, repository.ErrPRNotExists)
+
+	report, err = svc.pr.ImportPullRequests(ctx, records[:2], true)
+	require.NoError(t, err)
+	require.False(t, report.Applied)
+	require.True(t, report.Items[1].ReviewersAssigned)
+
+	report, err = svc.pr.ImportPullRequests(ctx, records[:2], false)
+	require.NoError(t, err)
+	require.True(t, report.Applied)
+	require.Equal(t, usecases.ImportStatusImported, report.Items[1].Status)
+
+	pr, err := svc.pr.GetPullRequest(ctx, "pr-1")
+	require.NoError(t, err)
+	require.Equal(t, domain.PRMerged, pr.Status)
+	require.Equal(t, []string{"u3"}, pr.Reviewers)
+	require.True(t, createdAt.Equal(*pr.CreatedAt))
+	require.True(t, mergedAt.Equal(*pr.MergedAt))
+
+	pr, err = svc.pr.GetPullRequest(ctx, "pr-2")
+	require.NoError(t, err)
+	require.Len(t, pr.Reviewers, 2)
+	require.NotContains(t, pr.Reviewers, "u1")
+
+	report, err = svc.pr.ImportPullRequests(ctx, records[:1], false)
+	require.NoError(t, err)
+	require.Equal(t, "PR already exists", report.Items[0].Problems[0].Message)
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+	svc := newServices()
+	createTeam(t, svc, "backend", "u1", "u2", "u3")
+	createTeam(t, svc, "frontend", "f1")
+
+	_, err := svc.pr.CreatePullRequest(ctx, &domain.PullRequest{ID: "pr-1", Name: "feature", AuthorID: "u1"})
+	require.NoError(t, err)
+
+	export := func(filter domain.ExportFilter, entities ...domain.ExportEntity) []string {
+		var keys []string
+
+		err := svc.export.Export(ctx, entities, filter, func(rec *domain.ExportRecord) error {
+			switch rec.Entity {
+			case domain.ExportTeams:
+				keys = append(keys, "team:"+rec.Team.Name)
+			case domain.ExportUsers:
+				keys = append(keys, "user:"+rec.User.ID)
+			case domain.ExportPullRequests:
+				keys = append(keys, "pr:"+rec.PR.ID)
+			case domain.ExportReviews:
+				keys = append(keys, "review:"+rec.Review.PullRequestID+"/"+rec.Review.UserID)
+			}
+
+			return nil
+		})
+		require.NoError(t, err)
+
+		return keys
+	}
+
+	require.Equal(t, []string{
+		"team:backend", "user:u1", "user:u2", "user:u3", "pr:pr-1", "review:pr-1/u2", "review:pr-1/u3",
+	}, export(domain.ExportFilter{TeamName: "backend"}, domain.ExportEntities...))
+	require.Equal(t, []string{"user:f1"}, export(domain.ExportFilter{TeamName: "frontend"}, domain.ExportUsers))
+
+	since := time.Now()
+	_, err = svc.pr.Merge(ctx, "pr-1", 0)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"pr:pr-1"}, export(domain.ExportFilter{UpdatedSince: &since}, domain.ExportEntities...))
+
+	err = svc.export.Export(ctx, domain.ExportEntities, domain.ExportFilter{TeamName: "nope"}, nil)
+	require.ErrorIs(t, err, repository.ErrTeamNotExists)
+}
+
+func TestExportDoesNotBlockWriters(t *testing.T) {
+	ctx := context.Background()
+	svc := newServices()
+	createTeam(t, svc, "backend", "u1", "u2")
+
+	_, err := svc.pr.CreatePullRequest(ctx, &domain.PullRequest{ID: "pr-1", Name: "feature", AuthorID: "u1"})
+	require.NoError(t, err)
+
+	// The export is stuck writing its first record until the merge completes.
+	merged := make(chan error)
+	var statuses []domain.PRStatus
+
+	err = svc.export.Export(ctx, []domain.ExportEntity{domain.ExportPullRequests}, domain.ExportFilter{}, func(rec *domain.ExportRecord) error {
+		go func() {
+			_, err := svc.pr.Merge(ctx, "pr-1", 0)
+			merged <- err
+		}()
+
+		select {
+		case err := <-merged:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("merge is blocked by export")
+		}
+
+		statuses = append(statuses, rec.PR.Status)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	// The export keeps the data set it has started with.
+	require.Equal(t, []domain.PRStatus{domain.PROpen}, statuses)
+}
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.Background()
+	src := newServices()
+	createTeam(t, src, "backend", "u1", "u2", "u3")
+
+	_, err := src.pr.CreatePullRequest(ctx, &domain.PullRequest{ID: "pr-1", Name: "feature", AuthorID: "u1"})
+	require.NoError(t, err)
+	_, err = src.pr.Merge(ctx, "pr-1", 0)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	w, err := backup.NewWriter(&buf, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, src.export.Export(ctx, domain.BackupEntities, domain.ExportFilter{}, w.Write))
+	require.NoError(t, w.Close())
+
+	dumpAll := func(svc *services) []*domain.ExportRecord {
+		var records []*domain.ExportRecord
+
+		err := svc.export.Export(ctx, domain.BackupEntities, domain.ExportFilter{}, func(rec *domain.ExportRecord) error {
+			records = append(records, rec)
+			return nil
+		})
+		require.NoError(t, err)
+
+		return records
+	}
+
+	store := memory.NewStore()
+	dst := &services{export: service.NewExportService(store, memory.NewTeamRepo(store), memory.NewExportRepo(store))}
+	restoreSvc := service.NewRestoreService(store, memory.NewRestoreRepo(store))
+
+	// The bootstrap key is registered at startup, its audit entry does not prevent restore.
+	authSvc := service.NewAuthService(store, memory.NewAPIKeyRepo(store), memory.NewAuditRepo(store))
+	require.NoError(t, authSvc.BootstrapKey(ctx, "avt_bootstrap"))
+
+	r, err := backup.NewReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	counts, err := restoreSvc.Restore(ctx, r.Next)
+	require.NoError(t, err)
+	require.Equal(t, map[domain.ExportEntity]int{
+		domain.ExportTeams: 1, domain.ExportUsers: 3, domain.ExportPullRequests: 1,
+		domain.ExportReviews: 2, domain.ExportAudit: 3,
+	}, counts)
+
+	want, got := dumpAll(src), dumpAll(dst)
+	require.Len(t, got, len(want)+1)
+
+	// Restored audit entries follow the one of the bootstrap key.
+	bootstrap := got[len(got)-4]
+	require.Equal(t, domain.AuditTargetAPIKey, bootstrap.Audit.TargetType)
+	got = slices.Delete(got, len(got)-4, len(got)-3)
+
+	for i := range want {
+		require.Equal(t, want[i].Entity, got[i].Entity)
+		require.True(t, want[i].UpdatedAt.Equal(got[i].UpdatedAt))
+
+		if want[i].Entity == domain.ExportAudit {
+			require.Equal(t, want[i].Audit.ID+bootstrap.Audit.ID, got[i].Audit.ID)
+		}
+	}
+
+	// Restore requires an empty storage.
+	r, err = backup.NewReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	_, err = restoreSvc.Restore(ctx, r.Next)
+	require.ErrorIs(t, err, usecases.ErrRestoreNotEmpty)
+
+	// Records referencing missing ones are rejected and nothing is restored.
+	store = memory.NewStore()
+	restoreSvc = service.NewRestoreService(store, memory.NewRestoreRepo(store))
+	records := []*domain.ExportRecord{
+		{Entity: domain.ExportTeams, Team: &domain.Team{Name: "backend"}},
+		{Entity: domain.ExportUsers, User: &domain.User{ID: "u1", TeamName: "frontend", Role: domain.RoleMiddle}},
+	}
+
+	_, err = restoreSvc.Restore(ctx, func() (*domain.ExportRecord, error) {
+		if len(records) == 0 {
+			return nil, io.EOF
+		}
+
+		rec := records[0]
+		records = records[1:]
+
+		return rec, nil
+	})
+	require.ErrorIs(t, err, usecases.ErrBackupInvalid)
+	require.ErrorContains(t, err, "user u1 references missing team frontend")
+
+	empty, err := memory.NewRestoreRepo(store).IsEmpty(ctx)
+	require.NoError(t, err)
+	require.True(t, empty)
+}
@@ -6,130 +6,128 @@ import (
 	"avito-task/internal/usecases"
 	"context"
 	"fmt"
-
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
+	"log/slog"
 )
 
 type TeamService struct {
-	pool *pgxpool.Pool
+	txManager repository.TxManager
 	teamRepo repository.TeamRepo
 	userRepo repository.UserRepo
 	prRepo repository.PullRequestRepo
+	auditRepo repository.AuditRepo
 }
 
 func NewTeamService(
-	pool *pgxpool.Pool,
+	txManager repository.TxManager,
 	teamRepo repository.TeamRepo,
 	userRepo repository.UserRepo,
 	prRepo repository.PullRequestRepo,
+	auditRepo repository.AuditRepo,
 ) *TeamService {
 	return &TeamService{
-		pool: pool,
+		txManager: txManager,
 		teamRepo: teamRepo,
 		userRepo: userRepo,
 		prRepo: prRepo,
+		auditRepo: auditRepo,
 	}
 }
 
 func (s *TeamService) CreateTeam(ctx context.Context, team *domain.Team) (*domain.Team, error) {
 	const op = "TeamService.CreateTeam"
 
-	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
-		IsoLevel: pgx.RepeatableRead,
-	})
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
 
-	if err != nil {
-		return nil, fmt.Errorf("%s: failed to begin tx: %w", op, err)
-	}
+	err := s.txManager.WithinTx(ctx, repository.TxOptions{
+		IsoLevel: repository.RepeatableRead,
+	}, func(ctx context.Context) error {
+		exists, err := s.teamRepo.CreateTeam(ctx, team)
+		if err != nil {
+			return err
+		}
 
-	defer func() { _ = tx.Rollback(ctx) }()
+		if exists {
+			return usecases.ErrTeamNameExists
+		}
 
-	exists, err := s.teamRepo.CreateTeam(ctx, tx, team)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
+		for _, u := range team.Members {
+			u.TeamName = team.Name
+		}
 
-	if exists {
-		return nil, fmt.Errorf("%s: %w", op, usecases.ErrTeamNameExists)
-	}
+		if err = s.userRepo.UpsertUsers(ctx, team.Members); err != nil {
+			return err
+		}
 
-	for _, u := range team.Members {
-		u.TeamName = team.Name
-	}
+		return writeAudit(ctx, s.auditRepo, domain.AuditTeamCreate, domain.AuditTargetTeam, team.Name, nil, team)
+	})
 
-	if err = s.userRepo.UpsertUsers(ctx, tx, team.Members); err != nil {
+	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err = tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("%s: failed to commit tx: %w", op, err)
-	}
-
 	return team, nil
 }
 
 func (s *TeamService) GetTeam(ctx context.Context, name string) (*domain.Team, error) {
 	const op = "TeamService.GetTeam"
 
-	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
-		IsoLevel: pgx.RepeatableRead,
-		AccessMode: pgx.ReadOnly,
-	})
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
 
-	if err != nil {
-		return nil, fmt.Errorf("%s: failed to begin tx: %w", op, err)
-	}
+	var team *domain.Team
 
-	defer func() { _ = tx.Rollback(ctx) }()
+	err := s.txManager.WithinTx(ctx, repository.TxOptions{
+		IsoLevel: repository.RepeatableRead,
+		ReadOnly: true,
+	}, func(ctx context.Context) error {
+		var err error
 
-	team, err := s.teamRepo.GetByName(ctx, tx, name)
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
+		if team, err = s.teamRepo.GetByName(ctx, name); err != nil {
+			return err
+		}
+
+		team.Members, err = s.userRepo.GetByTeam(ctx, repository.GetByTeamOpts{TeamName: team.Name})
+
+		return err
+	})
 
-	team.Members, err = s.userRepo.GetByTeam(ctx, tx, repository.GetByTeamOpts{TeamName: team.Name})
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err = tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("%s: failed to commit tx: %w", op, err)
-	}
-
 	return team, nil
 }
 
 func (s *TeamService) GetTeamStats(ctx context.Context, name string) (*domain.TeamStats, error) {
 	const op = "TeamService.GetTeamStats"
 
-	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
-		IsoLevel: pgx.RepeatableRead,
-		AccessMode: pgx.ReadOnly,
-	})
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
 
-	if err != nil {
-		return nil, fmt.Errorf("%s: failed to begin tx: %w", op, err)
-	}
+	stats := domain.TeamStats{Name: name}
 
-	defer func() { _ = tx.Rollback(ctx) }()
+	err := s.txManager.WithinTx(ctx, repository.TxOptions{
+		IsoLevel: repository.RepeatableRead,
+		ReadOnly: true,
+	}, func(ctx context.Context) error {
+		var err error
 
-	if _, err = s.teamRepo.GetByName(ctx, tx, name); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
+		if _, err = s.teamRepo.GetByName(ctx, name); err != nil {
+			return err
+		}
 
-	stats := domain.TeamStats{Name: name}
+		if stats.Users, err = s.prRepo.GetUserReviewsCounts(ctx, name); err != nil {
+			return err
+		}
 
-	if stats.Users, err = s.prRepo.GetUserReviewsCounts(ctx, tx, name); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
+		stats.PRs, err = s.prRepo.GetPRReviewersCounts(ctx, name)
 
-	if stats.PRs, err = s.prRepo.GetPRReviewersCounts(ctx, tx, name); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
+		return err
+	})
 
-	if err = tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("%s: failed to commit tx: %w", op, err)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return &stats, nil
@@ -138,28 +136,42 @@ func (s *TeamService) GetTeamStats(ctx context.Context, name string) (*domain.Te
 func (s *TeamService) DeactivateTeam(ctx context.Context, name string) ([]*domain.User, error) {
 	const op = "TeamService.DeactivateTeam"
 
-	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{
-		IsoLevel: pgx.RepeatableRead,
-	})
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
 
-	if err != nil {
-		return nil, fmt.Errorf("%s: failed to begin tx: %w", op, err)
+	if !usecases.CanManageTeam(ctx, name) {
+		return nil, fmt.Errorf("%s: %w", op, usecases.ErrTeamAccessDenied)
 	}
 
-	defer func() { _ = tx.Rollback(ctx) }()
+	var users []*domain.User
 
-	if _, err = s.teamRepo.GetByName(ctx, tx, name); err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
+	err := s.txManager.WithinTx(ctx, repository.TxOptions{
+		IsoLevel: repository.RepeatableRead,
+	}, func(ctx context.Context) error {
+		if _, err := s.teamRepo.GetByName(ctx, name); err != nil {
+			return err
+		}
+
+		before, err := s.userRepo.GetByTeam(ctx, repository.GetByTeamOpts{TeamName: name})
+		if err != nil {
+			return err
+		}
+
+		if users, err = s.userRepo.DeactivateTeam(ctx, name); err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, s.auditRepo, domain.AuditTeamDeactivate, domain.AuditTargetTeam, name, before, users)
+	})
 
-	users, err := s.userRepo.DeactivateTeam(ctx, tx, name)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err = tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("%s: failed to commit tx: %w", op, err)
-	}
+	slog.InfoContext(ctx, "team deactivated",
+		slog.String("team_name", name),
+		slog.Int("deactivated_users", len(users)),
+	)
 
 	return users, nil
 }
@@ -0,0 +1,7 @@
+package service
+
+import "go.opentelemetry.io/otel"
+
+// tracer is resolved through the global provider, so spans are exported
+// only if tracing is enabled in config.
+var tracer = otel.Tracer("avito-task/internal/usecases/service")
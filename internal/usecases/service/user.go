@@ -3,39 +3,72 @@ package service
 import (
 	"avito-task/internal/domain"
 	"avito-task/internal/repository"
+	"avito-task/internal/usecases"
 	"context"
 	"fmt"
 )
 
 type UserService struct {
+	txManager repository.TxManager
 	userRepo repository.UserRepo
 	prRepo repository.PullRequestRepo
+	auditRepo repository.AuditRepo
 }
 
 func NewUserService(
+	txManager repository.TxManager,
 	userRepo repository.UserRepo,
 	prRepo repository.PullRequestRepo,
+	auditRepo repository.AuditRepo,
 ) *UserService {
 	return &UserService{
+		txManager: txManager,
 		userRepo: userRepo,
 		prRepo: prRepo,
+		auditRepo: auditRepo,
 	}
 }
 
 func (s *UserService) SetIsActive(ctx context.Context, id string, isActive bool) (*domain.User, error) {
 	const op = "UserService.SetIsActive"
 
-	user, err := s.userRepo.SetIsActive(ctx, id, isActive)
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	var updated *domain.User
+
+	err := s.txManager.WithinTx(ctx, repository.TxOptions{
+		IsoLevel: repository.RepeatableRead,
+	}, func(ctx context.Context) error {
+		user, err := s.userRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if !usecases.CanManageTeam(ctx, user.TeamName) {
+			return usecases.ErrTeamAccessDenied
+		}
+
+		if updated, err = s.userRepo.SetIsActive(ctx, id, isActive); err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, s.auditRepo, domain.AuditUserSetActive, domain.AuditTargetUser, id, user, updated)
+	})
+
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return user, nil
+	return updated, nil
 }
 
 func (s *UserService) GetReview(ctx context.Context, id string) ([]*domain.PullRequestShort, error) {
 	const op = "UserService.GetReview"
 
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
 	prs, err := s.prRepo.GetUserReviews(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -43,3 +76,31 @@ func (s *UserService) GetReview(ctx context.Context, id string) ([]*domain.PullR
 
 	return prs, nil
 }
+
+func (s *UserService) GetUsers(ctx context.Context, ids []string) ([]*domain.User, error) {
+	const op = "UserService.GetUsers"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	users, err := s.userRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+func (s *UserService) GetReviews(ctx context.Context, ids []string) (map[string][]*domain.PullRequestShort, error) {
+	const op = "UserService.GetReviews"
+
+	ctx, span := tracer.Start(ctx, op)
+	defer span.End()
+
+	reviews, err := s.prRepo.GetUsersReviews(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reviews, nil
+}
@@ -8,4 +8,7 @@ import (
 type UserService interface {
 	SetIsActive(ctx context.Context, id string, isActive bool) (*domain.User, error)
 	GetReview(ctx context.Context, id string) ([]*domain.PullRequestShort, error)
+	// GetUsers and GetReviews load many users at once, unknown IDs are skipped.
+	GetUsers(ctx context.Context, ids []string) ([]*domain.User, error)
+	GetReviews(ctx context.Context, ids []string) (map[string][]*domain.PullRequestShort, error)
 }
@@ -156,6 +156,7 @@ var (
 
 func WriteTarget(t *vegeta.Target) error {
 	generators[Rnd(len(generators))](t)
+	t.Header = http.Header{"X-Api-Key": []string{os.Getenv("API_KEY")}}
 	return nil
 }
 
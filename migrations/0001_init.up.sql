@@ -0,0 +1,42 @@
+-- The schema of init.sql that databases were created with before versioned migrations.
+-- Such databases already have it, so statements are idempotent and the migration is only recorded.
+
+CREATE TABLE IF NOT EXISTS teams (
+    name        varchar(100)    PRIMARY KEY
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id          varchar(100)    PRIMARY KEY,
+    name        varchar(100)    NOT NULL,
+    team_name   varchar(100)    REFERENCES teams(name) ON DELETE SET NULL ON UPDATE CASCADE,
+    is_active   bool            NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS users_team_name_idx ON users(team_name, is_active);
+
+DO $$
+BEGIN
+    CREATE TYPE pr_status AS ENUM ('OPEN', 'MERGED');
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END
+$$;
+
+CREATE TABLE IF NOT EXISTS pull_requests (
+    id          varchar(100)    PRIMARY KEY,
+    name        varchar(100)    NOT NULL,
+    author_id   varchar(100)    REFERENCES users(id),
+    status      pr_status       NOT NULL DEFAULT 'OPEN',
+    created_at  timestamp       DEFAULT CURRENT_TIMESTAMP,
+    merged_at   timestamp
+);
+
+CREATE INDEX IF NOT EXISTS prs_status_id_idx ON pull_requests(status, id);
+
+CREATE TABLE IF NOT EXISTS reviewers (
+    pr_id   varchar(100) NOT NULL REFERENCES pull_requests(id) ON DELETE CASCADE,
+    user_id varchar(100) NOT NULL REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS reviewers_pr_idx ON reviewers(pr_id);
+CREATE INDEX IF NOT EXISTS reviewers_user_pr_idx ON reviewers(user_id, pr_id);
@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS role;
+ALTER TABLE teams DROP COLUMN IF EXISTS required_roles;
+DROP TYPE IF EXISTS user_role;
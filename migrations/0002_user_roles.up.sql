@@ -0,0 +1,12 @@
+-- Seniority of users and roles a team requires among reviewers of its PRs.
+
+DO $$
+BEGIN
+    CREATE TYPE user_role AS ENUM ('junior', 'middle', 'senior', 'lead');
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END
+$$;
+
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS required_roles user_role[] NOT NULL DEFAULT '{}';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role user_role NOT NULL DEFAULT 'middle';
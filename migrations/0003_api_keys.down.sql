@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS api_keys;
+DROP TYPE IF EXISTS access_role;
@@ -0,0 +1,19 @@
+-- API keys with access roles, only SHA-256 hashes of the keys are stored.
+
+DO $$
+BEGIN
+    CREATE TYPE access_role AS ENUM ('admin', 'team-lead', 'member', 'integration');
+EXCEPTION
+    WHEN duplicate_object THEN NULL;
+END
+$$;
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id          varchar(100)    PRIMARY KEY,
+    name        varchar(100)    NOT NULL,
+    key_hash    char(64)        NOT NULL UNIQUE,
+    role        access_role     NOT NULL,
+    team_name   varchar(100)    REFERENCES teams(name) ON DELETE CASCADE ON UPDATE CASCADE,
+    created_at  timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at  timestamp
+);
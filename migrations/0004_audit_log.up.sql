@@ -0,0 +1,17 @@
+-- Log of mutating operations with the state of the target before and after.
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id          bigserial       PRIMARY KEY,
+    actor_id    varchar(100)    NOT NULL,
+    actor_role  varchar(20)     NOT NULL,
+    action      varchar(50)     NOT NULL,
+    target_type varchar(20)     NOT NULL,
+    target_id   varchar(100)    NOT NULL,
+    before      jsonb,
+    after       jsonb,
+    request_id  varchar(100),
+    created_at  timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_target_idx ON audit_log(target_type, target_id, id);
+CREATE INDEX IF NOT EXISTS audit_log_actor_idx ON audit_log(actor_id, id);
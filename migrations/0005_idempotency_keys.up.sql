@@ -0,0 +1,13 @@
+CREATE TABLE idempotency_keys (
+    scope        varchar(100)    NOT NULL,
+    key          varchar(255)    NOT NULL,
+    fingerprint  char(64)        NOT NULL,
+    status_code  smallint,
+    headers      jsonb,
+    body         bytea,
+    created_at   timestamp       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at   timestamp       NOT NULL,
+    PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX idempotency_keys_expires_idx ON idempotency_keys(expires_at);
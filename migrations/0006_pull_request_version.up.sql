@@ -0,0 +1 @@
+ALTER TABLE pull_requests ADD COLUMN version bigint NOT NULL DEFAULT 1;
@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS reviewers_updated_at ON reviewers;
+DROP TRIGGER IF EXISTS pull_requests_updated_at ON pull_requests;
+DROP TRIGGER IF EXISTS users_updated_at ON users;
+DROP TRIGGER IF EXISTS teams_updated_at ON teams;
+DROP FUNCTION IF EXISTS set_updated_at();
+
+ALTER TABLE reviewers DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE users DROP COLUMN IF EXISTS updated_at;
+ALTER TABLE teams DROP COLUMN IF EXISTS updated_at;
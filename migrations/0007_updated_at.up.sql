@@ -0,0 +1,27 @@
+ALTER TABLE teams ADD COLUMN updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE users ADD COLUMN updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE pull_requests ADD COLUMN updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE reviewers ADD COLUMN updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+UPDATE pull_requests SET updated_at = COALESCE(merged_at, created_at, updated_at);
+
+CREATE FUNCTION set_updated_at() RETURNS trigger AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER teams_updated_at BEFORE UPDATE ON teams
+    FOR EACH ROW WHEN (OLD IS DISTINCT FROM NEW) EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER users_updated_at BEFORE UPDATE ON users
+    FOR EACH ROW WHEN (OLD IS DISTINCT FROM NEW) EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER pull_requests_updated_at BEFORE UPDATE ON pull_requests
+    FOR EACH ROW WHEN (OLD IS DISTINCT FROM NEW) EXECUTE FUNCTION set_updated_at();
+CREATE TRIGGER reviewers_updated_at BEFORE UPDATE ON reviewers
+    FOR EACH ROW WHEN (OLD IS DISTINCT FROM NEW) EXECUTE FUNCTION set_updated_at();
+
+CREATE INDEX teams_updated_at_idx ON teams(updated_at);
+CREATE INDEX users_updated_at_idx ON users(updated_at);
+CREATE INDEX prs_updated_at_idx ON pull_requests(updated_at);
+CREATE INDEX reviewers_updated_at_idx ON reviewers(updated_at);
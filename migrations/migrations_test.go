@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"avito-task/pkg/database/postgres"
+	"io/fs"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrationsAreValid(t *testing.T) {
+	m, err := postgres.NewMigrator(nil, FS)
+	require.NoError(t, err)
+	require.Positive(t, m.Latest())
+}
+
+// Databases created by init.sql before versioned migrations already have the schema
+// of the first migrations, so these must not fail on existing objects.
+func TestInitSchemaMigrationsAreIdempotent(t *testing.T) {
+	unguarded := regexp.MustCompile(`(CREATE TABLE|CREATE INDEX|ADD COLUMN) (?:[^I]|I[^F])`)
+
+	files, err := fs.Glob(FS, "000[1-4]_*.up.sql")
+	require.NoError(t, err)
+	require.Len(t, files, 4)
+
+	for _, name := range files {
+		body, err := fs.ReadFile(FS, name)
+		require.NoError(t, err)
+
+		script := string(body)
+
+		require.Empty(t, unguarded.FindAllString(script, -1), "%s: objects must be created IF NOT EXISTS", name)
+		require.Equal(t, strings.Count(script, "CREATE TYPE"), strings.Count(script, "WHEN duplicate_object"),
+			"%s: types must be created in blocks ignoring duplicate_object", name)
+	}
+}
@@ -4,13 +4,23 @@ import "flag"
 
 type AppFlags struct {
 	ConfigPath string
+	// Command is the optional subcommand (e.g. migrate), empty for running the server.
+	Command string
+	Args    []string
 }
 
 func ParseFlags() AppFlags {
 	configPath := flag.String("config", "./config/config.yaml", "path to config")
 	flag.Parse()
 
-	return AppFlags{
+	flags := AppFlags{
 		ConfigPath: *configPath,
 	}
+
+	if flag.NArg() > 0 {
+		flags.Command = flag.Arg(0)
+		flags.Args = flag.Args()[1:]
+	}
+
+	return flags
 }
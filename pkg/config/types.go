@@ -8,3 +8,10 @@ type HTTPConfig struct {
 	WriteTimeout time.Duration `yaml:"write_timeout" env-default:"10s"`
 	IdleTimeout  time.Duration `yaml:"idle_timeout" env-default:"5s"`
 }
+
+type GRPCConfig struct {
+	Enabled bool   `yaml:"enabled" env:"GRPC_ENABLED" env-default:"false"`
+	Address string `yaml:"address" env:"GRPC_ADDRESS" env-default:"0.0.0.0:9090"`
+	// StopTimeout bounds graceful stop, calls still running after it are cancelled.
+	StopTimeout time.Duration `yaml:"stop_timeout" env-default:"3s"`
+}
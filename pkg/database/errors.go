@@ -6,5 +6,7 @@ var (
 	ErrNotNullViolation = errors.New("not null violation")
 	ErrForeignKeyViolation = errors.New("foreign key violation")
 	ErrUniqueViolation = errors.New("unique violation")
+	ErrSerializationFailure = errors.New("serialization failure")
+	ErrDeadlockDetected = errors.New("deadlock detected")
 	ErrUndocumented = errors.New("undocumented database error")
 )
@@ -28,7 +28,14 @@ func NewPostgresPool(cfg Config) (*pgxpool.Pool, error) {
 		cfg.Host, cfg.Port, cfg.DbName, cfg.User, cfg.Password,
 	)
 
-	pool, err := pgxpool.New(ctx, pginfo)
+	poolCfg, err := pgxpool.ParseConfig(pginfo)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+
+	poolCfg.ConnConfig.Tracer = NewQueryTracer()
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", method, err)
 	}
@@ -11,6 +11,8 @@ const (
 	PgNotNullViolation = "23502"
 	PgForeignKeyViolation = "23503"
 	PgUniqueViolation = "23505"
+	PgSerializationFailure = "40001"
+	PgDeadlockDetected = "40P01"
 )
 
 var (
@@ -18,6 +20,8 @@ var (
 		PgNotNullViolation: database.ErrNotNullViolation,
 		PgForeignKeyViolation: database.ErrForeignKeyViolation,
 		PgUniqueViolation: database.ErrUniqueViolation,
+		PgSerializationFailure: database.ErrSerializationFailure,
+		PgDeadlockDetected: database.ErrDeadlockDetected,
 	}
 )
 
@@ -34,3 +38,11 @@ func DetectError(err error) error {
 
 	return database.ErrUndocumented
 }
+
+// IsRetryable reports whether the transaction failed with err may succeed when run again,
+// i.e. it was aborted because of a concurrent transaction.
+func IsRetryable(err error) bool {
+	dbErr := DetectError(err)
+
+	return errors.Is(dbErr, database.ErrSerializationFailure) || errors.Is(dbErr, database.ErrDeadlockDetected)
+}
@@ -0,0 +1,21 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRetryable(t *testing.T) {
+	wrap := func(code string) error {
+		return fmt.Errorf("Repo.Op: %w", &pgconn.PgError{Code: code})
+	}
+
+	assert.True(t, IsRetryable(wrap(PgSerializationFailure)))
+	assert.True(t, IsRetryable(wrap(PgDeadlockDetected)))
+	assert.False(t, IsRetryable(wrap(PgUniqueViolation)))
+	assert.False(t, IsRetryable(errors.New("connection refused")))
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"avito-task/pkg/health"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrSchemaVersion = errors.New("unexpected schema version")
+
+const getSchemaVersionQuery = `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`
+
+func PingCheck(pool *pgxpool.Pool) health.Check {
+	return pool.Ping
+}
+
+// SchemaVersionCheck fails if the database is not migrated to expected version yet.
+// The schema migrated by a newer release is reported as a warning only: migrations keep
+// the previous release working, so its replicas stay in rotation during rolling deploys.
+func SchemaVersionCheck(pool *pgxpool.Pool, expected int64) health.Check {
+	return func(ctx context.Context) error {
+		var version int64
+
+		if err := pool.QueryRow(ctx, getSchemaVersionQuery).Scan(&version); err != nil {
+			return err
+		}
+
+		switch {
+		case version < expected:
+			return fmt.Errorf("%w: got %d, expected %d", ErrSchemaVersion, version, expected)
+		case version > expected:
+			return health.Warn(fmt.Errorf("%w: got %d newer than expected %d", ErrSchemaVersion, version, expected))
+		}
+
+		return nil
+	}
+}
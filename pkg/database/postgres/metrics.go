@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolCollector exports pgxpool.Stat() on every scrape.
+type PoolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns     *prometheus.Desc
+	idleConns         *prometheus.Desc
+	totalConns        *prometheus.Desc
+	maxConns          *prometheus.Desc
+	acquireCount      *prometheus.Desc
+	acquireDuration   *prometheus.Desc
+	emptyAcquireCount *prometheus.Desc
+	canceledAcquires  *prometheus.Desc
+}
+
+func NewPoolCollector(pool *pgxpool.Pool) *PoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc("db_pool_"+name, help, nil, nil)
+	}
+
+	return &PoolCollector{
+		pool:              pool,
+		acquiredConns:     desc("acquired_connections", "Number of currently acquired connections."),
+		idleConns:         desc("idle_connections", "Number of currently idle connections."),
+		totalConns:        desc("total_connections", "Total number of connections in the pool."),
+		maxConns:          desc("max_connections", "Maximum size of the pool."),
+		acquireCount:      desc("acquires_total", "Number of successful acquires from the pool."),
+		acquireDuration:   desc("acquire_duration_seconds_total", "Total time spent on successful acquires."),
+		emptyAcquireCount: desc("empty_acquires_total", "Number of acquires that waited for a connection."),
+		canceledAcquires:  desc("canceled_acquires_total", "Number of acquires canceled by context."),
+	}
+}
+
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.emptyAcquireCount
+	ch <- c.canceledAcquires
+}
+
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	st := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(st.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(st.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(st.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(st.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(st.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, st.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(st.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquires, prometheus.CounterValue, float64(st.CanceledAcquireCount()))
+}
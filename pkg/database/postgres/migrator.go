@@ -0,0 +1,282 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// migrationLockKey is the pg_advisory_lock key held while migrating,
+// so replicas started at the same time apply migrations one by one.
+const migrationLockKey = 7_320_115_042
+
+const (
+	createMigrationsTableQuery = `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version     bigint      PRIMARY KEY,
+			applied_at  timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`
+
+	getAppliedVersionsQuery = `SELECT version FROM schema_migrations ORDER BY version`
+	insertVersionQuery      = `INSERT INTO schema_migrations (version) VALUES ($1)`
+	deleteVersionQuery      = `DELETE FROM schema_migrations WHERE version = $1`
+)
+
+var (
+	ErrMigrationNoDown = errors.New("migration has no down script")
+	ErrMigrationFiles  = errors.New("invalid migration files")
+
+	migrationFileRe = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Migrator struct {
+	pool       *pgxpool.Pool
+	migrations []Migration
+}
+
+// NewMigrator loads migrations from the root of fsys.
+func NewMigrator(pool *pgxpool.Pool, fsys fs.FS) (*Migrator, error) {
+	const method = "postgres.NewMigrator"
+
+	migrations, err := loadMigrations(fsys)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+
+	return &Migrator{
+		pool:       pool,
+		migrations: migrations,
+	}, nil
+}
+
+func loadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+
+	for _, e := range entries {
+		if e.IsDir() || path.Ext(e.Name()) != ".sql" {
+			continue
+		}
+
+		match := migrationFileRe.FindStringSubmatch(e.Name())
+		if match == nil {
+			return nil, fmt.Errorf("%w: unexpected file name %s", ErrMigrationFiles, e.Name())
+		}
+
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+
+		body, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("%w: version %d has different names", ErrMigrationFiles, version)
+		}
+
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("%w: version %d has no up script", ErrMigrationFiles, m.Version)
+		}
+
+		migrations = append(migrations, *m)
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return int(a.Version - b.Version)
+	})
+
+	return migrations, nil
+}
+
+// Latest returns the version of the newest known migration.
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the newest applied migration version (0 if nothing is applied).
+func (m *Migrator) Version(ctx context.Context) (int64, error) {
+	const method = "postgres.Migrator.Version"
+
+	var version int64
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if len(applied) > 0 {
+			version = applied[len(applied)-1]
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", method, err)
+	}
+
+	return version, nil
+}
+
+// Up applies all pending migrations in version order, each in its own transaction,
+// and returns versions that were applied.
+func (m *Migrator) Up(ctx context.Context) ([]int64, error) {
+	const method = "postgres.Migrator.Up"
+
+	var done []int64
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, mig := range m.migrations {
+			if slices.Contains(applied, mig.Version) {
+				continue
+			}
+
+			if err = apply(ctx, conn, mig.Up, insertVersionQuery, mig.Version); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, err)
+			}
+
+			done = append(done, mig.Version)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return done, fmt.Errorf("%s: %w", method, err)
+	}
+
+	return done, nil
+}
+
+// Down reverts up to steps latest applied migrations and returns reverted versions.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]int64, error) {
+	const method = "postgres.Migrator.Down"
+
+	var done []int64
+
+	err := m.withLock(ctx, func(conn *pgxpool.Conn) error {
+		applied, err := appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(applied) - 1; i >= 0 && len(done) < steps; i-- {
+			idx := slices.IndexFunc(m.migrations, func(mig Migration) bool {
+				return mig.Version == applied[i]
+			})
+			if idx < 0 {
+				return fmt.Errorf("%w: applied version %d is unknown", ErrMigrationFiles, applied[i])
+			}
+
+			mig := m.migrations[idx]
+			if mig.Down == "" {
+				return fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, ErrMigrationNoDown)
+			}
+
+			if err = apply(ctx, conn, mig.Down, deleteVersionQuery, mig.Version); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", mig.Version, mig.Name, err)
+			}
+
+			done = append(done, mig.Version)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return done, fmt.Errorf("%s: %w", method, err)
+	}
+
+	return done, nil
+}
+
+// withLock runs fn on a dedicated connection holding the migration advisory lock.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := m.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err = conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+		return err
+	}
+
+	defer func() {
+		_, _ = conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockKey)
+	}()
+
+	if _, err = conn.Exec(ctx, createMigrationsTableQuery); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+func appliedVersions(ctx context.Context, conn *pgxpool.Conn) ([]int64, error) {
+	rows, err := conn.Query(ctx, getAppliedVersionsQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[int64])
+}
+
+// apply executes migration script and records the change of version in one transaction.
+func apply(ctx context.Context, conn *pgxpool.Conn, script string, versionQuery string, version int64) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err = tx.Exec(ctx, script); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, versionQuery, version); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_add_index.up.sql":   {Data: []byte("CREATE INDEX i ON t(a);")},
+		"0002_add_index.down.sql": {Data: []byte("DROP INDEX i;")},
+		"0001_init.up.sql":        {Data: []byte("CREATE TABLE t (a int);")},
+		"0010_no_down.up.sql":     {Data: []byte("SELECT 1;")},
+		"migrations.go":           {Data: []byte("package migrations")},
+	}
+
+	migrations, err := loadMigrations(fsys)
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+
+	require.Equal(t, int64(1), migrations[0].Version)
+	require.Equal(t, "init", migrations[0].Name)
+	require.Empty(t, migrations[0].Down)
+
+	require.Equal(t, int64(2), migrations[1].Version)
+	require.Equal(t, "DROP INDEX i;", migrations[1].Down)
+
+	m := &Migrator{migrations: migrations}
+	require.Equal(t, int64(10), m.Latest())
+}
+
+func TestLoadMigrationsInvalid(t *testing.T) {
+	cases := map[string]fstest.MapFS{
+		"bad name": {
+			"init.sql": {Data: []byte("SELECT 1;")},
+		},
+		"down only": {
+			"0001_init.down.sql": {Data: []byte("SELECT 1;")},
+		},
+		"name mismatch": {
+			"0001_init.up.sql":    {Data: []byte("SELECT 1;")},
+			"0001_other.down.sql": {Data: []byte("SELECT 1;")},
+		},
+	}
+
+	for name, fsys := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := loadMigrations(fsys)
+			require.ErrorIs(t, err, ErrMigrationFiles)
+		})
+	}
+}
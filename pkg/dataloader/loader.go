@@ -0,0 +1,140 @@
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchFunc loads values of all keys at once. Keys missing in the result are loaded as zero values.
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+type result[V any] struct {
+	value V
+	err   error
+	done  chan struct{}
+}
+
+type batch[K comparable, V any] struct {
+	ctx     context.Context
+	keys    []K
+	results []*result[V]
+	timer   *time.Timer
+}
+
+// Loader collects keys requested within the wait window into one BatchFunc call and caches
+// loaded values, so it must live no longer than a single request.
+type Loader[K comparable, V any] struct {
+	fetch    BatchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	cache   map[K]*result[V]
+	pending *batch[K, V]
+}
+
+// New creates loader; maxBatch <= 0 means batches are limited only by the wait window.
+func New[K comparable, V any](fetch BatchFunc[K, V], wait time.Duration, maxBatch int) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		cache:    make(map[K]*result[V]),
+	}
+}
+
+// Load returns value of the key, waiting for the batch it was put in.
+// The batch is loaded with ctx of its first key.
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+
+	res, ok := l.cache[key]
+	if !ok {
+		res = &result[V]{done: make(chan struct{})}
+		l.cache[key] = res
+		l.enqueue(ctx, key, res)
+	}
+
+	l.mu.Unlock()
+
+	select {
+	case <-res.done:
+		return res.value, res.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// LoadMany loads values of all keys in their order.
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, error) {
+	values := make([]V, len(keys))
+	errs := make([]error, len(keys))
+
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			values[i], errs[i] = l.Load(ctx, key)
+		}()
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// enqueue must be called with l.mu held.
+func (l *Loader[K, V]) enqueue(ctx context.Context, key K, res *result[V]) {
+	if l.pending == nil {
+		b := &batch[K, V]{ctx: ctx}
+		b.timer = time.AfterFunc(l.wait, func() {
+			l.mu.Lock()
+			if l.pending != b {
+				l.mu.Unlock()
+				return
+			}
+
+			l.pending = nil
+			l.mu.Unlock()
+
+			l.dispatch(b)
+		})
+
+		l.pending = b
+	}
+
+	b := l.pending
+	b.keys = append(b.keys, key)
+	b.results = append(b.results, res)
+
+	if l.maxBatch > 0 && len(b.keys) >= l.maxBatch {
+		b.timer.Stop()
+		l.pending = nil
+
+		go l.dispatch(b)
+	}
+}
+
+func (l *Loader[K, V]) dispatch(b *batch[K, V]) {
+	values, err := l.fetch(b.ctx, b.keys)
+
+	for i, res := range b.results {
+		if err != nil {
+			res.err = err
+		} else {
+			res.value = values[b.keys[i]]
+		}
+
+		close(res.done)
+	}
+}
@@ -0,0 +1,76 @@
+package dataloader_test
+
+import (
+	"avito-task/pkg/dataloader"
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoaderBatchesConcurrentLoads(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		batches [][]int
+	)
+
+	loader := dataloader.New(func(_ context.Context, keys []int) (map[int]string, error) {
+		mu.Lock()
+		batches = append(batches, slices.Clone(keys))
+		mu.Unlock()
+
+		res := make(map[int]string, len(keys))
+		for _, k := range keys {
+			if k != 0 {
+				res[k] = strconv.Itoa(k)
+			}
+		}
+
+		return res, nil
+	}, 5*time.Millisecond, 0)
+
+	values, err := loader.LoadMany(context.Background(), []int{3, 1, 2, 1, 0})
+	require.NoError(t, err)
+	require.Equal(t, []string{"3", "1", "2", "1", ""}, values)
+
+	value, err := loader.Load(context.Background(), 2)
+	require.NoError(t, err)
+	require.Equal(t, "2", value)
+
+	require.Len(t, batches, 1)
+	require.ElementsMatch(t, []int{0, 1, 2, 3}, batches[0])
+}
+
+func TestLoaderSplitsByMaxBatch(t *testing.T) {
+	var calls sync.WaitGroup
+	calls.Add(2)
+
+	loader := dataloader.New(func(_ context.Context, keys []int) (map[int]int, error) {
+		defer calls.Done()
+		require.Len(t, keys, 2)
+
+		return map[int]int{keys[0]: keys[0], keys[1]: keys[1]}, nil
+	}, time.Hour, 2)
+
+	values, err := loader.LoadMany(context.Background(), []int{1, 2, 3, 4})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3, 4}, values)
+
+	calls.Wait()
+}
+
+func TestLoaderReturnsBatchError(t *testing.T) {
+	errLoad := errors.New("load failed")
+
+	loader := dataloader.New(func(context.Context, []string) (map[string]int, error) {
+		return nil, errLoad
+	}, time.Millisecond, 0)
+
+	_, err := loader.LoadMany(context.Background(), []string{"a", "b"})
+	require.ErrorIs(t, err, errLoad)
+}
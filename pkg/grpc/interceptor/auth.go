@@ -0,0 +1,54 @@
+package interceptor
+
+import (
+	"context"
+	"slices"
+
+	pkgMiddleware "avito-task/pkg/http/middleware"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Authenticator extracts principal from call metadata.
+// It returns nil principal without error if call carries no credentials at all.
+type Authenticator func(ctx context.Context, md metadata.MD) (*pkgMiddleware.Principal, error)
+
+// ErrorMapper converts error of authentication or authorization to the status returned to client.
+type ErrorMapper func(ctx context.Context, err error) error
+
+// Authenticate puts principal into call context, the same one as HTTP middleware does,
+// so it can be read with middleware.PrincipalFromContext.
+func Authenticate(authn Authenticator, onError ErrorMapper) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		p, err := authn(ctx, md)
+		if err != nil {
+			return nil, onError(ctx, err)
+		}
+
+		if p != nil {
+			ctx = pkgMiddleware.WithPrincipal(ctx, p)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// RequireRoles allows calls of every method only for principals with one of its roles.
+// Methods missing in methodRoles are denied.
+func RequireRoles(methodRoles map[string][]string, onError ErrorMapper) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		p, ok := pkgMiddleware.PrincipalFromContext(ctx)
+		if !ok {
+			return nil, onError(ctx, pkgMiddleware.ErrUnauthorized)
+		}
+
+		if !slices.Contains(methodRoles[info.FullMethod], p.Role) {
+			return nil, onError(ctx, pkgMiddleware.ErrForbidden)
+		}
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,30 @@
+package interceptor
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Recovery turns panic of the handler into Internal status.
+func Recovery() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				slog.ErrorContext(ctx, "grpc handler panicked",
+					slog.String("method", info.FullMethod),
+					slog.Any("panic", rec),
+					slog.String("stack", string(debug.Stack())),
+				)
+
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,41 @@
+package interceptor
+
+import (
+	"avito-task/pkg/requestid"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// RequestIDKey is the metadata key of request ID, the same as X-Request-ID header of the HTTP API.
+	RequestIDKey    = "x-request-id"
+	requestIDBytes  = 16
+	maxRequestIDLen = 100
+)
+
+// RequestID takes request ID from x-request-id metadata or generates a new one,
+// puts it into call context and returns it in the response header.
+func RequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var id string
+
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(RequestIDKey); len(values) > 0 {
+				id = values[0]
+			}
+		}
+
+		if len(id) == 0 || len(id) > maxRequestIDLen {
+			buf := make([]byte, requestIDBytes)
+			_, _ = rand.Read(buf)
+			id = hex.EncodeToString(buf)
+		}
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+		return handler(requestid.WithID(ctx, id), req)
+	}
+}
@@ -0,0 +1,146 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check reports whether a component is operational.
+type Check func(ctx context.Context) error
+
+// warning is the error of the component that is still operational.
+type warning struct {
+	err error
+}
+
+func (w *warning) Error() string {
+	return w.err.Error()
+}
+
+func (w *warning) Unwrap() error {
+	return w.err
+}
+
+// Warn wraps err returned by Check, so that the component is reported up with err as a warning.
+func Warn(err error) error {
+	return &warning{err: err}
+}
+
+type ComponentStatus struct {
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Warning  string `json:"warning,omitempty"`
+	Duration string `json:"duration"`
+}
+
+type Report struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components,omitempty"`
+}
+
+// Checker runs registered readiness checks concurrently, each bounded by timeout.
+type Checker struct {
+	timeout time.Duration
+	names   []string
+	checks  map[string]Check
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+		checks:  make(map[string]Check),
+	}
+}
+
+func (c *Checker) Register(name string, check Check) *Checker {
+	if _, ok := c.checks[name]; !ok {
+		c.names = append(c.names, name)
+	}
+
+	c.checks[name] = check
+
+	return c
+}
+
+func (c *Checker) Run(ctx context.Context) Report {
+	report := Report{
+		Status:     StatusUp,
+		Components: make(map[string]ComponentStatus, len(c.names)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for _, name := range c.names {
+		wg.Add(1)
+
+		go func(name string, check Check) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check(checkCtx)
+
+			status := ComponentStatus{
+				Status:   StatusUp,
+				Duration: time.Since(start).String(),
+			}
+
+			var warn *warning
+			if errors.As(err, &warn) {
+				status.Warning = err.Error()
+			} else if err != nil {
+				status.Status = StatusDown
+				status.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			report.Components[name] = status
+			if status.Status != StatusUp {
+				report.Status = StatusDown
+			}
+		}(name, c.checks[name])
+	}
+
+	wg.Wait()
+
+	return report
+}
+
+// LiveHandler reports that the process is running and able to serve requests.
+func LiveHandler(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, Report{Status: StatusUp})
+}
+
+// ReadyHandler responds with 200 if all checks pass and 503 otherwise.
+func (c *Checker) ReadyHandler(w http.ResponseWriter, r *http.Request) {
+	report := c.Run(r.Context())
+
+	code := http.StatusOK
+	if report.Status != StatusUp {
+		code = http.StatusServiceUnavailable
+	}
+
+	writeJSON(w, code, report)
+}
+
+func writeJSON(w http.ResponseWriter, code int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(report)
+}
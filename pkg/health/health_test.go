@@ -0,0 +1,50 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadyHandler(t *testing.T) {
+	checker := NewChecker(50*time.Millisecond).
+		Register("db", func(context.Context) error { return nil }).
+		Register("schema", func(context.Context) error { return errors.New("outdated") }).
+		Register("newer", func(context.Context) error { return Warn(errors.New("newer schema")) }).
+		Register("slow", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+	rec := httptest.NewRecorder()
+	checker.ReadyHandler(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var report Report
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&report))
+	require.Equal(t, StatusDown, report.Status)
+	require.Equal(t, StatusUp, report.Components["db"].Status)
+	require.Equal(t, StatusDown, report.Components["schema"].Status)
+	require.Equal(t, "outdated", report.Components["schema"].Error)
+	require.Equal(t, StatusUp, report.Components["newer"].Status)
+	require.Equal(t, "newer schema", report.Components["newer"].Warning)
+	require.Equal(t, StatusDown, report.Components["slow"].Status)
+}
+
+func TestReadyHandlerAllUp(t *testing.T) {
+	checker := NewChecker(time.Second).
+		Register("db", func(context.Context) error { return nil }).
+		Register("schema", func(context.Context) error { return Warn(errors.New("newer schema")) })
+
+	rec := httptest.NewRecorder()
+	checker.ReadyHandler(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+}
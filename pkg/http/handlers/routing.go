@@ -1,11 +1,14 @@
 package handlers
 
 import (
+	"avito-task/pkg/health"
 	pkgMiddleware "avito-task/pkg/http/middleware"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type RouterOption func(r chi.Router)
@@ -24,12 +27,65 @@ func WithLogger() RouterOption {
 	}
 }
 
+func WithRequestID() RouterOption {
+	return func(r chi.Router) {
+		r.Use(pkgMiddleware.RequestID)
+	}
+}
+
+func WithTracing() RouterOption {
+	return func(r chi.Router) {
+		r.Use(pkgMiddleware.Tracing)
+	}
+}
+
 func WithRecovery() RouterOption {
 	return func(r chi.Router) {
 		r.Use(middleware.Recoverer)
 	}
 }
 
+func WithAuth(authn pkgMiddleware.Authenticator, onError pkgMiddleware.ErrorWriter) RouterOption {
+	return func(r chi.Router) {
+		r.Use(pkgMiddleware.Authenticate(authn, onError))
+	}
+}
+
+// WithIdempotency replays responses to POST requests of routes registered by opts repeated
+// with the same Idempotency-Key, it must go after WithAuth.
+func WithIdempotency(store pkgMiddleware.IdempotencyStore, onError pkgMiddleware.ErrorWriter, opts ...RouterOption) RouterOption {
+	return func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(pkgMiddleware.Idempotency(store, onError))
+
+			for _, opt := range opts {
+				opt(r)
+			}
+		})
+	}
+}
+
+// WithOpenAPIValidation rejects requests not matching the specification, nil validator disables it.
+func WithOpenAPIValidation(v *pkgMiddleware.OpenAPIValidator, onError pkgMiddleware.ErrorWriter) RouterOption {
+	return func(r chi.Router) {
+		if v != nil {
+			r.Use(v.Middleware(onError))
+		}
+	}
+}
+
+func WithMetrics(m *pkgMiddleware.HTTPMetrics) RouterOption {
+	return func(r chi.Router) {
+		r.Use(m.Middleware)
+	}
+}
+
+func WithMetricsHandler(path string, gatherer prometheus.Gatherer) RouterOption {
+	return func(r chi.Router) {
+		r.Handle(path, promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{}))
+	}
+}
+
 func WithSwagger(path string, fsRoot string) RouterOption {
 	srv := http.FileServer(http.Dir(fsRoot))
 
@@ -38,12 +94,12 @@ func WithSwagger(path string, fsRoot string) RouterOption {
 	}
 }
 
-func WithHealthHandler() RouterOption {
+// WithHealthHandler registers liveness and readiness probes.
+// Plain /health is kept as an alias of liveness for older clients.
+func WithHealthHandler(checker *health.Checker) RouterOption {
 	return func(r chi.Router) {
-		r.Mount("/health", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			w.Header().Set("Content-Type", "text/plain")
-			_, _ = w.Write([]byte("OK"))
-		}))
+		r.Get("/health", health.LiveHandler)
+		r.Get("/health/live", health.LiveHandler)
+		r.Get("/health/ready", checker.ReadyHandler)
 	}
 }
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+)
+
+var (
+	ErrUnauthorized = errors.New("authentication required")
+	ErrForbidden    = errors.New("access denied for this role")
+)
+
+// Principal is an authenticated client of the API.
+type Principal struct {
+	ID       string
+	Role     string
+	TeamName string
+}
+
+// Authenticator extracts principal from request credentials.
+// It returns nil principal without error if request carries no credentials at all.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// ErrorWriter writes error response for failed authentication or authorization.
+type ErrorWriter func(w http.ResponseWriter, r *http.Request, err error)
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok && p != nil
+}
+
+// Authenticate puts principal into request context. Anonymous requests are passed as is,
+// routes requiring authentication must be protected with RequireRoles.
+func Authenticate(authn Authenticator, onError ErrorWriter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, err := authn.Authenticate(r)
+			if err != nil {
+				onError(w, r, err)
+				return
+			}
+
+			if p != nil {
+				r = r.WithContext(WithPrincipal(r.Context(), p))
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireRoles allows only principals with one of the given roles.
+func RequireRoles(onError ErrorWriter, roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			p, ok := PrincipalFromContext(r.Context())
+			if !ok {
+				onError(w, r, ErrUnauthorized)
+				return
+			}
+
+			if !slices.Contains(roles, p.Role) {
+				onError(w, r, ErrForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"avito-task/pkg/logger"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+var (
+	ErrInvalidIdempotencyKey = errors.New("Idempotency-Key must not be longer than 255 characters")
+
+	// replayedHeaders are response headers stored along with the body.
+	replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+	// fingerprintHeaders are request headers that change the meaning of the request.
+	fingerprintHeaders = []string{"Content-Type", "If-Match"}
+)
+
+// CachedResponse is a response stored for the idempotency key.
+type CachedResponse struct {
+	StatusCode int
+	Headers    map[string]string
+	Body       []byte
+}
+
+// IdempotencyStore keeps responses by idempotency keys. Keys are unique within scope,
+// fingerprint identifies the request sent with the key.
+type IdempotencyStore interface {
+	// Begin claims the key. It returns the stored response if the same request has
+	// already been processed, and nil if the request must be processed now.
+	Begin(ctx context.Context, scope, key, fingerprint string) (*CachedResponse, error)
+	Complete(ctx context.Context, scope, key, fingerprint string, resp *CachedResponse) error
+	// Abort releases the key, so that the request may be sent again.
+	Abort(ctx context.Context, scope, key, fingerprint string) error
+}
+
+func fingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+
+	for _, name := range fingerprintHeaders {
+		h.Write([]byte(name + ": " + r.Header.Get(name) + "\n"))
+	}
+
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Idempotency replays the first response to POST requests repeated with the same
+// Idempotency-Key header. Keys are scoped by principal, so anonymous requests and
+// requests without the header are passed as is. Server errors are not stored and
+// release the key for another attempt. Responses are stored as they are, so it must
+// not wrap endpoints returning secrets or large bodies.
+func Idempotency(store IdempotencyStore, onError ErrorWriter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			p, ok := PrincipalFromContext(r.Context())
+
+			if r.Method != http.MethodPost || len(key) == 0 || !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				onError(w, r, ErrInvalidIdempotencyKey)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				onError(w, r, err)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			fp := fingerprint(r, body)
+
+			cached, err := store.Begin(r.Context(), p.ID, key, fp)
+			if err != nil {
+				onError(w, r, err)
+				return
+			}
+
+			if cached != nil {
+				for name, value := range cached.Headers {
+					w.Header().Set(name, value)
+				}
+
+				w.Header().Set(IdempotentReplayedHeader, "true")
+				w.WriteHeader(cached.StatusCode)
+				_, _ = w.Write(cached.Body)
+
+				return
+			}
+
+			// The response has been sent by the time it is stored, so the request
+			// cancellation must not prevent it.
+			ctx := context.WithoutCancel(r.Context())
+			completed := false
+
+			defer func() {
+				if completed {
+					return
+				}
+
+				if err := store.Abort(ctx, p.ID, key, fp); err != nil {
+					slog.ErrorContext(ctx, "failed to release idempotency key", logger.Err(err))
+				}
+			}()
+
+			var buf bytes.Buffer
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ww.Tee(&buf)
+
+			next.ServeHTTP(ww, r)
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			if status >= http.StatusInternalServerError {
+				return
+			}
+
+			headers := make(map[string]string, len(replayedHeaders))
+			for _, name := range replayedHeaders {
+				if value := ww.Header().Get(name); len(value) > 0 {
+					headers[name] = value
+				}
+			}
+
+			if err := store.Complete(ctx, p.ID, key, fp, &CachedResponse{
+				StatusCode: status,
+				Headers:    headers,
+				Body:       buf.Bytes(),
+			}); err != nil {
+				slog.ErrorContext(ctx, "failed to store idempotent response", logger.Err(err))
+				return
+			}
+
+			completed = true
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var errKeyReused = errors.New("key reused")
+
+type storedKey struct {
+	fingerprint string
+	resp        *CachedResponse
+}
+
+// mapStore is IdempotencyStore keeping keys in a map.
+type mapStore map[string]*storedKey
+
+func (s mapStore) Begin(_ context.Context, scope, key, fingerprint string) (*CachedResponse, error) {
+	stored, ok := s[scope+"/"+key]
+	if !ok {
+		s[scope+"/"+key] = &storedKey{fingerprint: fingerprint}
+		return nil, nil
+	}
+
+	if stored.fingerprint != fingerprint {
+		return nil, errKeyReused
+	}
+
+	return stored.resp, nil
+}
+
+func (s mapStore) Complete(_ context.Context, scope, key, _ string, resp *CachedResponse) error {
+	s[scope+"/"+key].resp = resp
+	return nil
+}
+
+func (s mapStore) Abort(_ context.Context, scope, key, _ string) error {
+	delete(s, scope+"/"+key)
+	return nil
+}
+
+func TestIdempotency(t *testing.T) {
+	store := mapStore{}
+	calls := 0
+	status := http.StatusCreated
+
+	handler := Idempotency(store, func(w http.ResponseWriter, _ *http.Request, err error) {
+		require.ErrorIs(t, err, errKeyReused)
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	})(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"`+strconv.Itoa(calls)+`"`)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"call":` + strconv.Itoa(calls) + `}`))
+	}))
+
+	send := func(key, body string, headers ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", strings.NewReader(body))
+		req = req.WithContext(WithPrincipal(req.Context(), &Principal{ID: "client"}))
+		req.Header.Set(IdempotencyKeyHeader, key)
+
+		for i := 0; i+1 < len(headers); i += 2 {
+			req.Header.Set(headers[i], headers[i+1])
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	first := send("k1", `{"id":"pr-1"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+
+	repeated := send("k1", `{"id":"pr-1"}`)
+	require.Equal(t, http.StatusCreated, repeated.Code)
+	require.Equal(t, first.Body.String(), repeated.Body.String())
+	require.Equal(t, "application/json", repeated.Header().Get("Content-Type"))
+	require.Equal(t, `"1"`, repeated.Header().Get("ETag"))
+	require.Equal(t, "true", repeated.Header().Get(IdempotentReplayedHeader))
+	require.Equal(t, 1, calls)
+
+	require.Equal(t, http.StatusUnprocessableEntity, send("k1", `{"id":"pr-2"}`).Code)
+	require.Equal(t, http.StatusUnprocessableEntity, send("k1", `{"id":"pr-1"}`, "If-Match", `"5"`).Code)
+	require.Equal(t, 1, calls)
+
+	status = http.StatusInternalServerError
+	require.Equal(t, http.StatusInternalServerError, send("k2", `{}`).Code)
+	require.NotContains(t, store, "client/k2")
+
+	status = http.StatusOK
+	require.Equal(t, http.StatusOK, send("k2", `{}`).Code)
+	require.Equal(t, 3, calls)
+}
@@ -1,7 +1,7 @@
 package middleware
 
 import (
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -15,9 +15,18 @@ func Logger(next http.Handler) http.Handler {
 
 		next.ServeHTTP(ww, r)
 
-		log.Printf(
-			"[HTTP/1.1] | %s | %d | %s | %s | %s",
-			r.RemoteAddr, ww.Status(), r.Method, r.URL.Path, time.Since(start).String(),
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		slog.InfoContext(r.Context(), "http request",
+			slog.String("proto", r.Proto),
+			slog.String("remote_addr", r.RemoteAddr),
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(start)),
 		)
 	})
 }
@@ -0,0 +1,252 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+)
+
+var unsupportedProperty = regexp.MustCompile(`^property "(.+)" is unsupported$`)
+
+// FieldError describes one part of request or response that does not match the specification.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists all invalid fields of a request.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", f.Field, f.Message))
+	}
+
+	return "request validation failed: " + strings.Join(msgs, "; ")
+}
+
+// OpenAPIValidator checks requests (and optionally responses) of operations described
+// in OpenAPI document, other routes are passed as is.
+type OpenAPIValidator struct {
+	router            routers.Router
+	validateResponses bool
+}
+
+// NewOpenAPIValidator loads and validates the document; basePath is the prefix of all its paths.
+func NewOpenAPIValidator(spec []byte, basePath string, validateResponses bool) (*OpenAPIValidator, error) {
+	const op = "NewOpenAPIValidator"
+
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	doc.Servers = openapi3.Servers{{URL: strings.TrimSuffix(basePath, "/")}}
+
+	registerTextBodyDecoders(doc)
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &OpenAPIValidator{
+		router:            router,
+		validateResponses: validateResponses,
+	}, nil
+}
+
+// Middleware rejects requests not matching the specification with *ValidationError.
+// Mismatching responses are only logged, since they are already sent.
+func (v *OpenAPIValidator) Middleware(onError ErrorWriter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route, pathParams, err := v.router.FindRoute(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Clients are not required to send Content-Type, handlers always decode JSON.
+			if r.ContentLength != 0 && len(r.Header.Get("Content-Type")) == 0 {
+				r.Header.Set("Content-Type", "application/json")
+			}
+
+			reqInput := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: pathParams,
+				Route:      route,
+				Options: &openapi3filter.Options{
+					MultiError:         true,
+					AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+				},
+			}
+
+			if err = openapi3filter.ValidateRequest(r.Context(), reqInput); err != nil {
+				onError(w, r, &ValidationError{Fields: fieldErrors(err)})
+				return
+			}
+
+			if !v.validateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rec := &bodyRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			err = openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: reqInput,
+				Status:                 rec.status,
+				Header:                 rec.Header(),
+				Body:                   io.NopCloser(bytes.NewReader(rec.body.Bytes())),
+				Options: &openapi3filter.Options{
+					MultiError:            true,
+					IncludeResponseStatus: true,
+				},
+			})
+			if err != nil {
+				slog.ErrorContext(r.Context(), "response does not match API specification",
+					slog.Int("status", rec.status),
+					slog.Any("fields", fieldErrors(err)),
+				)
+			}
+		})
+	}
+}
+
+// registerTextBodyDecoders lets bodies of media types unknown to kin-openapi (e.g. NDJSON)
+// be validated as strings, handlers parse and render them on their own.
+func registerTextBodyDecoders(doc *openapi3.T) {
+	register := func(content openapi3.Content) {
+		for contentType := range content {
+			if openapi3filter.RegisteredBodyDecoder(contentType) == nil {
+				openapi3filter.RegisterBodyDecoder(contentType, openapi3filter.FileBodyDecoder)
+			}
+		}
+	}
+
+	for _, item := range doc.Paths.Map() {
+		for _, operation := range item.Operations() {
+			if operation.RequestBody != nil && operation.RequestBody.Value != nil {
+				register(operation.RequestBody.Value.Content)
+			}
+
+			if operation.Responses == nil {
+				continue
+			}
+
+			for _, resp := range operation.Responses.Map() {
+				if resp.Value != nil {
+					register(resp.Value.Content)
+				}
+			}
+		}
+	}
+}
+
+// fieldErrors flattens errors of kin-openapi into a list of fields with their problems.
+func fieldErrors(err error) []FieldError {
+	var (
+		reqErr    *openapi3filter.RequestError
+		respErr   *openapi3filter.ResponseError
+		schemaErr *openapi3.SchemaError
+	)
+
+	// MultiError is checked without unwrapping, since its As matches any of the errors
+	// and would lose the RequestError they are nested into.
+	if multi, ok := err.(openapi3.MultiError); ok {
+		var fields []FieldError
+		for _, e := range multi {
+			fields = append(fields, fieldErrors(e)...)
+		}
+
+		return fields
+	}
+
+	switch {
+	case errors.As(err, &reqErr):
+		field := "body"
+		if reqErr.Parameter != nil {
+			field = reqErr.Parameter.Name
+		}
+
+		if reqErr.Err == nil {
+			return []FieldError{{Field: field, Message: reqErr.Reason}}
+		}
+
+		return prefixFields(field, reqErr.Parameter != nil, fieldErrors(reqErr.Err))
+
+	case errors.As(err, &respErr):
+		if respErr.Err == nil {
+			return []FieldError{{Field: "response", Message: respErr.Reason}}
+		}
+
+		return prefixFields("response", false, fieldErrors(respErr.Err))
+
+	case errors.As(err, &schemaErr):
+		path := schemaErr.JSONPointer()
+
+		// Unknown property is reported on its object, point at the property itself.
+		if m := unsupportedProperty.FindStringSubmatch(schemaErr.Reason); m != nil {
+			path = append(path, m[1])
+		}
+
+		return []FieldError{{Field: strings.Join(path, "."), Message: schemaErr.Reason}}
+	}
+
+	return []FieldError{{Message: err.Error()}}
+}
+
+// prefixFields names fields of body by their JSON path, of parameters by parameter name.
+func prefixFields(field string, isParam bool, fields []FieldError) []FieldError {
+	for i := range fields {
+		switch {
+		case len(fields[i].Field) == 0 || isParam:
+			fields[i].Field = field
+		case field == "response":
+			fields[i].Field = field + "." + fields[i].Field
+		}
+	}
+
+	return fields
+}
+
+type bodyRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *bodyRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer (e.g. to flush streams).
+func (r *bodyRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `
+openapi: 3.0.3
+info: { title: test, version: "1" }
+paths:
+  /team/add:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [ team_name, members ]
+              properties:
+                team_name: { type: string, maxLength: 5 }
+                members:
+                  type: array
+                  items:
+                    type: object
+                    required: [ user_id ]
+                    additionalProperties: false
+                    properties:
+                      user_id: { type: string }
+      responses:
+        '201': { description: created }
+  /team/get:
+    get:
+      parameters:
+        - { name: team_name, in: query, required: true, schema: { type: string } }
+      responses:
+        '200': { description: ok }
+`
+
+func TestOpenAPIValidator(t *testing.T) {
+	v, err := NewOpenAPIValidator([]byte(testSpec), "/", false)
+	require.NoError(t, err)
+
+	var fields []FieldError
+
+	handler := v.Middleware(func(w http.ResponseWriter, _ *http.Request, err error) {
+		var verr *ValidationError
+		require.True(t, errors.As(err, &verr))
+
+		fields = verr.Fields
+		w.WriteHeader(http.StatusBadRequest)
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Body != nil {
+			body, _ := io.ReadAll(r.Body)
+			_, _ = w.Write(body)
+		}
+	}))
+
+	send := func(method, target, body string) *httptest.ResponseRecorder {
+		fields = nil
+
+		var reader io.Reader
+		if len(body) > 0 {
+			reader = strings.NewReader(body)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, target, reader))
+
+		return rec
+	}
+
+	rec := send(http.MethodPost, "/team/add", `{"team_name":"back","members":[{"user_id":"u1"}]}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, `{"team_name":"back","members":[{"user_id":"u1"}]}`, rec.Body.String())
+
+	rec = send(http.MethodPost, "/team/add", `{"team_name":"backend","members":[{}]}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.ElementsMatch(t, []string{"team_name", "members.0.user_id"}, fieldNames(fields))
+
+	rec = send(http.MethodPost, "/team/add", `{"team_name":"back","members":[{"user_id":"u1","name":"x"}]}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, []string{"members.0.name"}, fieldNames(fields))
+
+	rec = send(http.MethodGet, "/team/get", "")
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, []string{"team_name"}, fieldNames(fields))
+
+	rec = send(http.MethodGet, "/health/live", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func fieldNames(fields []FieldError) []string {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.Field)
+	}
+
+	return names
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"avito-task/pkg/requestid"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+	requestIDBytes  = 16
+	maxRequestIDLen = 100
+)
+
+// RequestID takes request ID from X-Request-ID header or generates a new one,
+// puts it into request context and echoes it in the response header.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+
+		if len(id) == 0 || len(id) > maxRequestIDLen {
+			buf := make([]byte, requestIDBytes)
+			_, _ = rand.Read(buf)
+			id = hex.EncodeToString(buf)
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(requestid.WithID(r.Context(), id)))
+	})
+}
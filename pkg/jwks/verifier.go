@@ -0,0 +1,160 @@
+package jwks
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid bearer token")
+	ErrUnknownKey   = errors.New("unknown signing key")
+)
+
+type Config struct {
+	JWKSPath string        `yaml:"jwks_path" env:"AUTH_JWKS_PATH"`
+	Issuer   string        `yaml:"issuer" env:"AUTH_JWT_ISSUER"`
+	Audience string        `yaml:"audience" env:"AUTH_JWT_AUDIENCE"`
+	Leeway   time.Duration `yaml:"leeway" env-default:"30s"`
+}
+
+// jsonWebKey is a subset of RFC 7517 fields required for RSA and HMAC keys.
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	K   string `json:"k"`
+}
+
+type keySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// Verifier checks HS256/RS256 tokens against keys loaded from JWKS file.
+type Verifier struct {
+	keys   map[string]any
+	parser *jwt.Parser
+}
+
+func NewVerifier(cfg Config) (*Verifier, error) {
+	const op = "jwks.NewVerifier"
+
+	data, err := os.ReadFile(cfg.JWKSPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	keys, err := parseKeySet(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithLeeway(cfg.Leeway),
+		jwt.WithExpirationRequired(),
+	}
+
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &Verifier{
+		keys:   keys,
+		parser: jwt.NewParser(opts...),
+	}, nil
+}
+
+func parseKeySet(data []byte) (map[string]any, error) {
+	var set keySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+
+	for _, k := range set.Keys {
+		switch k.Kty {
+		case "RSA":
+			n, err := base64.RawURLEncoding.DecodeString(k.N)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", k.Kid, err)
+			}
+
+			e, err := base64.RawURLEncoding.DecodeString(k.E)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", k.Kid, err)
+			}
+
+			keys[k.Kid] = &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			}
+		case "oct":
+			secret, err := base64.RawURLEncoding.DecodeString(k.K)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", k.Kid, err)
+			}
+
+			keys[k.Kid] = secret
+		default:
+			return nil, fmt.Errorf("key %q: unsupported key type %q", k.Kid, k.Kty)
+		}
+	}
+
+	return keys, nil
+}
+
+// keyFunc picks key by token "kid" header (or the only key of the set) and makes sure
+// that its type matches the signing method, so HMAC can't be verified with public RSA key.
+func (v *Verifier) keyFunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := v.keys[kid]
+
+	if !ok && kid == "" && len(v.keys) == 1 {
+		for _, k := range v.keys {
+			key, ok = k, true
+		}
+	}
+
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	switch token.Method.(type) {
+	case *jwt.SigningMethodRSA:
+		if _, isRSA := key.(*rsa.PublicKey); isRSA {
+			return key, nil
+		}
+	case *jwt.SigningMethodHMAC:
+		if _, isHMAC := key.([]byte); isHMAC {
+			return key, nil
+		}
+	}
+
+	return nil, ErrUnknownKey
+}
+
+// Verify validates token signature and registered claims and returns all token claims.
+func (v *Verifier) Verify(rawToken string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+
+	if _, err := v.parser.ParseWithClaims(rawToken, claims, v.keyFunc); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidToken, err.Error())
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,119 @@
+package jwks_test
+
+import (
+	"avito-task/pkg/jwks"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+var hmacSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func writeJWKS(t *testing.T, pub *rsa.PublicKey) string {
+	t.Helper()
+
+	set := map[string]any{
+		"keys": []map[string]string{
+			{
+				"kid": "rsa-1",
+				"kty": "RSA",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			},
+			{
+				"kid": "hmac-1",
+				"kty": "oct",
+				"alg": "HS256",
+				"k":   base64.RawURLEncoding.EncodeToString(hmacSecret),
+			},
+		},
+	}
+
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	return path
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	return signed
+}
+
+func TestVerifier(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	verifier, err := jwks.NewVerifier(jwks.Config{
+		JWKSPath: writeJWKS(t, &priv.PublicKey),
+		Issuer:   "sso",
+	})
+	require.NoError(t, err)
+
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":  "u1",
+			"team": "backend",
+			"iss":  "sso",
+			"exp":  time.Now().Add(time.Hour).Unix(),
+		}
+	}
+
+	t.Run("RS256", func(t *testing.T) {
+		claims, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, "rsa-1", priv, valid()))
+		require.NoError(t, err)
+		require.Equal(t, "u1", claims["sub"])
+		require.Equal(t, "backend", claims["team"])
+	})
+
+	t.Run("HS256", func(t *testing.T) {
+		_, err := verifier.Verify(sign(t, jwt.SigningMethodHS256, "hmac-1", hmacSecret, valid()))
+		require.NoError(t, err)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		claims := valid()
+		claims["exp"] = time.Now().Add(-time.Hour).Unix()
+
+		_, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, "rsa-1", priv, claims))
+		require.ErrorIs(t, err, jwks.ErrInvalidToken)
+	})
+
+	t.Run("WrongIssuer", func(t *testing.T) {
+		claims := valid()
+		claims["iss"] = "other"
+
+		_, err := verifier.Verify(sign(t, jwt.SigningMethodHS256, "hmac-1", hmacSecret, claims))
+		require.ErrorIs(t, err, jwks.ErrInvalidToken)
+	})
+
+	t.Run("UnknownKid", func(t *testing.T) {
+		_, err := verifier.Verify(sign(t, jwt.SigningMethodHS256, "hmac-2", hmacSecret, valid()))
+		require.ErrorIs(t, err, jwks.ErrInvalidToken)
+	})
+
+	t.Run("AlgorithmConfusion", func(t *testing.T) {
+		_, err := verifier.Verify(sign(t, jwt.SigningMethodHS256, "rsa-1", hmacSecret, valid()))
+		require.ErrorIs(t, err, jwks.ErrInvalidToken)
+	})
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"avito-task/pkg/requestid"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type Config struct {
+	Level  string `yaml:"level" env:"LOG_LEVEL" env-default:"info"`
+	Format string `yaml:"format" env:"LOG_FORMAT" env-default:"json"`
+}
+
+// New creates logger writing records of the configured level and format to w.
+// Every record logged with context gets request_id and trace_id attributes if the context carries them.
+func New(cfg Config, w io.Writer) (*slog.Logger, error) {
+	const method = "logger.New"
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler
+
+	switch strings.ToLower(cfg.Format) {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	case FormatText:
+		handler = slog.NewTextHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("%s: unknown format %q", method, cfg.Format)
+	}
+
+	return slog.New(&contextHandler{Handler: handler}), nil
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, rec slog.Record) error {
+	if id := requestid.FromContext(ctx); id != "" {
+		rec.AddAttrs(slog.String("request_id", id))
+	}
+
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		rec.AddAttrs(
+			slog.String("trace_id", sc.TraceID().String()),
+			slog.String("span_id", sc.SpanID().String()),
+		)
+	}
+
+	return h.Handler.Handle(ctx, rec)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// Err is a shorthand for error attribute.
+func Err(err error) slog.Attr {
+	return slog.String("error", err.Error())
+}
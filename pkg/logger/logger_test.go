@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"avito-task/pkg/requestid"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerAddsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+
+	l, err := New(Config{Level: "debug", Format: FormatJSON}, &buf)
+	require.NoError(t, err)
+
+	ctx := requestid.WithID(context.Background(), "req-1")
+	l.With(slog.String("component", "test")).DebugContext(ctx, "hello")
+
+	var rec map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &rec))
+	require.Equal(t, "hello", rec["msg"])
+	require.Equal(t, "DEBUG", rec["level"])
+	require.Equal(t, "req-1", rec["request_id"])
+	require.Equal(t, "test", rec["component"])
+}
+
+func TestLoggerLevel(t *testing.T) {
+	var buf bytes.Buffer
+
+	l, err := New(Config{Level: "warn", Format: FormatText}, &buf)
+	require.NoError(t, err)
+
+	l.Info("skipped")
+	require.Empty(t, buf.String())
+
+	l.Warn("written")
+	require.Contains(t, buf.String(), "msg=written")
+}
+
+func TestLoggerInvalidConfig(t *testing.T) {
+	_, err := New(Config{Level: "verbose", Format: FormatJSON}, &bytes.Buffer{})
+	require.Error(t, err)
+
+	_, err = New(Config{Level: "info", Format: "xml"}, &bytes.Buffer{})
+	require.Error(t, err)
+}
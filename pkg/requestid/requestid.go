@@ -0,0 +1,15 @@
+package requestid
+
+import "context"
+
+type requestIDKey struct{}
+
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// FromContext returns request ID or empty string if context has no one.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
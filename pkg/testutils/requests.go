@@ -5,26 +5,47 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 )
 
+// Headers are added to every request made by MakeRequest (e.g. credentials).
+var Headers = http.Header{}
+
 func MakeRequest(t *testing.T, url, method, path string, body interface{}) (*http.Response, string) {
 	t.Helper()
 
-	var reqBody io.Reader
-	if body != nil {
-		jsonData, err := json.Marshal(body)
-		require.NoError(t, err)
-		reqBody = bytes.NewBuffer(jsonData)
+	if body == nil {
+		return do(t, url, method, path, "", nil)
 	}
 
+	jsonData, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	return do(t, url, method, path, "application/json", bytes.NewBuffer(jsonData))
+}
+
+// MakeRawRequest sends the body as is with given content type (e.g. CSV or NDJSON).
+func MakeRawRequest(t *testing.T, url, method, path, contentType, body string) (*http.Response, string) {
+	t.Helper()
+
+	return do(t, url, method, path, contentType, strings.NewReader(body))
+}
+
+func do(t *testing.T, url, method, path, contentType string, reqBody io.Reader) (*http.Response, string) {
+	t.Helper()
+
 	req, err := http.NewRequest(method, url+path, reqBody)
 	require.NoError(t, err)
 
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	if reqBody != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	for k, v := range Headers {
+		req.Header[k] = v
 	}
 
 	res, err := http.DefaultClient.Do(req)
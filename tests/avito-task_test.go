@@ -1,10 +1,12 @@
 package main_test
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,11 +19,13 @@ type TeamMember struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	IsActive bool   `json:"is_active"`
+	Role     string `json:"role,omitempty"`
 }
 
 type Team struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName              string       `json:"team_name"`
+	Members               []TeamMember `json:"members"`
+	RequiredReviewerRoles []string     `json:"required_reviewer_roles,omitempty"`
 }
 
 type User struct {
@@ -49,8 +53,13 @@ type PullRequestShort struct {
 }
 
 type ErrorDetail struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id"`
+	Fields    []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	} `json:"fields"`
 }
 
 type ErrorResponse struct {
@@ -61,9 +70,26 @@ func TestReviewerServiceIntegration(t *testing.T) {
 	require := require.New(t)
 
 	url := fmt.Sprintf("http://%s", os.Getenv("HTTP_ADDRESS"))
-	resHC, _ := tu.MakeRequest(t, url, "GET", "/health", nil)
+	adminKey := os.Getenv("API_KEY")
+	tu.Headers.Set("X-API-Key", adminKey)
+
+	resHC, _ := tu.MakeRequest(t, url, "GET", "/health/live", nil)
 	require.Equal(http.StatusOK, resHC.StatusCode)
 
+	var readyResponse struct {
+		Status     string `json:"status"`
+		Components map[string]struct {
+			Status string `json:"status"`
+		} `json:"components"`
+	}
+
+	resHC, body := tu.MakeRequest(t, url, "GET", "/health/ready", nil)
+	require.Equal(http.StatusOK, resHC.StatusCode)
+	require.NoError(json.Unmarshal([]byte(body), &readyResponse))
+	require.Equal("up", readyResponse.Status)
+	require.Equal("up", readyResponse.Components["postgres"].Status)
+	require.Equal("up", readyResponse.Components["schema"].Status)
+
 	var teamResponse struct {
 		Team Team `json:"team"`
 	}
@@ -169,6 +195,40 @@ func TestReviewerServiceIntegration(t *testing.T) {
 			"author_id":         "u1",
 		}
 
+		// The picker is seeded once at startup, so reviewers are known only for the first
+		// selection after the service is started with RANDOM_SEED=42 (as make launch_services_with_tests does).
+		t.Run("0_CreatePR_SeededReviewers", func(t *testing.T) {
+			if os.Getenv("RANDOM_SEED") != "42" {
+				t.Skip("reviewers are known for RANDOM_SEED=42 only")
+			}
+
+			seededTeam := Team{
+				TeamName: "seeded-devs",
+				Members: []TeamMember{
+					{UserID: "s1", Username: "Sam", IsActive: true},
+					{UserID: "s2", Username: "Sara", IsActive: true},
+					{UserID: "s3", Username: "Sean", IsActive: true},
+					{UserID: "s4", Username: "Sofia", IsActive: true},
+					{UserID: "s5", Username: "Steve", IsActive: true},
+					{UserID: "s6", Username: "Susan", IsActive: true},
+				},
+			}
+			res, _ := tu.MakeRequest(t, url, "POST", "/team/add", seededTeam)
+			require.Equal(http.StatusCreated, res.StatusCode)
+
+			payload := map[string]string{
+				"pull_request_id":   "pr-seeded",
+				"pull_request_name": "Seeded feature",
+				"author_id":         "s1",
+			}
+			res, body := tu.MakeRequest(t, url, "POST", "/pullRequest/create", payload)
+			require.Equal(http.StatusCreated, res.StatusCode)
+
+			err := json.Unmarshal([]byte(body), &prResponse)
+			require.NoError(err)
+			require.Equal([]string{"s5", "s6"}, prResponse.PR.AssignedReviewers)
+		})
+
 		t.Run("1_CreatePR_Success", func(t *testing.T) {
 			res, body := tu.MakeRequest(t, url, "POST", "/pullRequest/create", prPayload)
 			require.Equal(http.StatusCreated, res.StatusCode)
@@ -298,4 +358,634 @@ func TestReviewerServiceIntegration(t *testing.T) {
 			require.Equal(http.StatusNotFound, res.StatusCode)
 		})
 	})
+	t.Run("D_RequiredReviewerRoles", func(t *testing.T) {
+		rolesTeam := Team{
+			TeamName:              "mobile-devs",
+			RequiredReviewerRoles: []string{"senior", "lead"},
+			Members: []TeamMember{
+				{UserID: "m1", Username: "Eve", IsActive: true},
+				{UserID: "m2", Username: "Frank", IsActive: true, Role: "senior"},
+				{UserID: "m3", Username: "Grace", IsActive: true, Role: "junior"},
+				{UserID: "m4", Username: "Heidi", IsActive: true, Role: "middle"},
+				{UserID: "m5", Username: "Ivan", IsActive: false, Role: "lead"},
+			},
+		}
+
+		t.Run("1_CreateTeam_InvalidRole", func(t *testing.T) {
+			payload := Team{
+				TeamName: "invalid-roles",
+				Members:  []TeamMember{{UserID: "x1", Username: "X", IsActive: true, Role: "boss"}},
+			}
+			res, _ := tu.MakeRequest(t, url, "POST", "/team/add", payload)
+			require.Equal(http.StatusBadRequest, res.StatusCode)
+		})
+
+		t.Run("2_CreateTeam_Success", func(t *testing.T) {
+			res, body := tu.MakeRequest(t, url, "POST", "/team/add", rolesTeam)
+			require.Equal(http.StatusCreated, res.StatusCode)
+
+			err := json.Unmarshal([]byte(body), &teamResponse)
+			require.NoError(err)
+			require.ElementsMatch(rolesTeam.RequiredReviewerRoles, teamResponse.Team.RequiredReviewerRoles)
+		})
+
+		t.Run("3_CreatePR_SeniorAssigned", func(t *testing.T) {
+			payload := map[string]string{
+				"pull_request_id":   "pr-201",
+				"pull_request_name": "Mobile feature",
+				"author_id":         "m1",
+			}
+			res, body := tu.MakeRequest(t, url, "POST", "/pullRequest/create", payload)
+			require.Equal(http.StatusCreated, res.StatusCode)
+
+			err := json.Unmarshal([]byte(body), &prResponse)
+			require.NoError(err)
+			require.Len(prResponse.PR.AssignedReviewers, 2)
+			require.Contains(prResponse.PR.AssignedReviewers, "m2")
+		})
+
+		t.Run("4_Reassign_PreservesSenior", func(t *testing.T) {
+			payload := map[string]interface{}{
+				"user_id":   "m5",
+				"is_active": true,
+			}
+			res, _ := tu.MakeRequest(t, url, "POST", "/users/setIsActive", payload)
+			require.Equal(http.StatusOK, res.StatusCode)
+
+			reassignPayload := map[string]string{
+				"pull_request_id": "pr-201",
+				"old_reviewer_id": "m2",
+			}
+			res, body := tu.MakeRequest(t, url, "POST", "/pullRequest/reassign", reassignPayload)
+			require.Equal(http.StatusOK, res.StatusCode)
+
+			err := json.Unmarshal([]byte(body), &reassignResponse)
+			require.NoError(err)
+			require.Equal("m5", reassignResponse.ReplacedBy)
+		})
+	})
+	t.Run("E_Auth", func(t *testing.T) {
+		var issueResponse struct {
+			Key    string `json:"key"`
+			APIKey struct {
+				ID       string `json:"id"`
+				Role     string `json:"role"`
+				TeamName string `json:"team_name"`
+			} `json:"api_key"`
+		}
+
+		t.Cleanup(func() { tu.Headers.Set("X-API-Key", adminKey) })
+
+		t.Run("1_NoKey_Unauthorized", func(t *testing.T) {
+			tu.Headers.Del("X-API-Key")
+			defer tu.Headers.Set("X-API-Key", adminKey)
+
+			res, body := tu.MakeRequest(t, url, "GET", "/team/get?team_name=backend-devs", nil)
+			require.Equal(http.StatusUnauthorized, res.StatusCode)
+
+			err := json.Unmarshal([]byte(body), &errResponse)
+			require.NoError(err)
+			require.Equal("UNAUTHORIZED", errResponse.Error.Code)
+		})
+
+		t.Run("2_IssueKey_Success", func(t *testing.T) {
+			payload := map[string]string{
+				"name":      "backend member",
+				"role":      "member",
+				"team_name": "backend-devs",
+			}
+			res, body := tu.MakeRequest(t, url, "POST", "/apiKeys/issue", payload)
+			require.Equal(http.StatusCreated, res.StatusCode)
+
+			err := json.Unmarshal([]byte(body), &issueResponse)
+			require.NoError(err)
+			require.NotEmpty(issueResponse.Key)
+			require.Equal("member", issueResponse.APIKey.Role)
+		})
+
+		t.Run("3_MemberKey_Forbidden", func(t *testing.T) {
+			tu.Headers.Set("X-API-Key", issueResponse.Key)
+			defer tu.Headers.Set("X-API-Key", adminKey)
+
+			res, _ := tu.MakeRequest(t, url, "GET", "/team/get?team_name=backend-devs", nil)
+			require.Equal(http.StatusOK, res.StatusCode)
+
+			res, body := tu.MakeRequest(t, url, "POST", "/team/deactivate?team_name=backend-devs", nil)
+			require.Equal(http.StatusForbidden, res.StatusCode)
+
+			err := json.Unmarshal([]byte(body), &errResponse)
+			require.NoError(err)
+			require.Equal("FORBIDDEN", errResponse.Error.Code)
+		})
+
+		t.Run("4_RevokeKey_Success", func(t *testing.T) {
+			payload := map[string]string{"id": issueResponse.APIKey.ID}
+			res, _ := tu.MakeRequest(t, url, "POST", "/apiKeys/revoke", payload)
+			require.Equal(http.StatusOK, res.StatusCode)
+
+			tu.Headers.Set("X-API-Key", issueResponse.Key)
+			defer tu.Headers.Set("X-API-Key", adminKey)
+
+			res, _ = tu.MakeRequest(t, url, "GET", "/team/get?team_name=backend-devs", nil)
+			require.Equal(http.StatusUnauthorized, res.StatusCode)
+		})
+
+		t.Run("5_TeamLeadKey_OwnTeamOnly", func(t *testing.T) {
+			payload := map[string]string{
+				"name":      "mobile lead",
+				"role":      "team-lead",
+				"team_name": "mobile-devs",
+			}
+			res, body := tu.MakeRequest(t, url, "POST", "/apiKeys/issue", payload)
+			require.Equal(http.StatusCreated, res.StatusCode)
+			require.NoError(json.Unmarshal([]byte(body), &issueResponse))
+
+			tu.Headers.Set("X-API-Key", issueResponse.Key)
+			defer tu.Headers.Set("X-API-Key", adminKey)
+
+			res, _ = tu.MakeRequest(t, url, "POST", "/team/deactivate?team_name=backend-devs", nil)
+			require.Equal(http.StatusForbidden, res.StatusCode)
+
+			res, _ = tu.MakeRequest(t, url, "POST", "/users/setIsActive", map[string]interface{}{
+				"user_id":   "u1",
+				"is_active": false,
+			})
+			require.Equal(http.StatusForbidden, res.StatusCode)
+
+			res, _ = tu.MakeRequest(t, url, "POST", "/users/setIsActive", map[string]interface{}{
+				"user_id":   "m3",
+				"is_active": true,
+			})
+			require.Equal(http.StatusOK, res.StatusCode)
+		})
+	})
+	t.Run("F_Audit", func(t *testing.T) {
+		type AuditEntry struct {
+			ID        int64           `json:"id"`
+			ActorID   string          `json:"actor_id"`
+			Action    string          `json:"action"`
+			TargetID  string          `json:"target_id"`
+			Before    json.RawMessage `json:"before"`
+			After     json.RawMessage `json:"after"`
+			RequestID string          `json:"request_id"`
+		}
+
+		var auditResponse struct {
+			Entries    []AuditEntry `json:"entries"`
+			NextCursor int64        `json:"next_cursor"`
+		}
+
+		t.Run("1_PullRequestHistory", func(t *testing.T) {
+			res, body := tu.MakeRequest(t, url, "GET", "/audit?target_type=pull_request&target_id=pr-101", nil)
+			require.Equal(http.StatusOK, res.StatusCode)
+
+			err := json.Unmarshal([]byte(body), &auditResponse)
+			require.NoError(err)
+
+			actions := []string{}
+			for _, e := range auditResponse.Entries {
+				actions = append(actions, e.Action)
+				require.NotEmpty(e.ActorID)
+				require.NotEmpty(e.RequestID)
+			}
+
+			require.Equal([]string{
+				"pull_request.merge",
+				"pull_request.merge",
+				"pull_request.reassign",
+				"pull_request.create",
+			}, actions)
+		})
+
+		t.Run("2_Pagination", func(t *testing.T) {
+			res, body := tu.MakeRequest(t, url, "GET", "/audit?target_type=pull_request&target_id=pr-101&limit=3", nil)
+			require.Equal(http.StatusOK, res.StatusCode)
+			require.NoError(json.Unmarshal([]byte(body), &auditResponse))
+			require.Len(auditResponse.Entries, 3)
+			require.NotZero(auditResponse.NextCursor)
+
+			path := fmt.Sprintf("/audit?target_type=pull_request&target_id=pr-101&limit=3&cursor=%d", auditResponse.NextCursor)
+			res, body = tu.MakeRequest(t, url, "GET", path, nil)
+			require.Equal(http.StatusOK, res.StatusCode)
+			require.NoError(json.Unmarshal([]byte(body), &auditResponse))
+			require.Len(auditResponse.Entries, 1)
+			require.Equal("pull_request.create", auditResponse.Entries[0].Action)
+		})
+
+		t.Run("3_FilterByRequestID", func(t *testing.T) {
+			tu.Headers.Set("X-Request-ID", "audit-test-request")
+			payload := map[string]interface{}{"user_id": "u4", "is_active": true}
+			res, _ := tu.MakeRequest(t, url, "POST", "/users/setIsActive", payload)
+			tu.Headers.Del("X-Request-ID")
+			require.Equal(http.StatusOK, res.StatusCode)
+			require.Equal("audit-test-request", res.Header.Get("X-Request-ID"))
+
+			res, body := tu.MakeRequest(t, url, "GET", "/audit?request_id=audit-test-request", nil)
+			require.Equal(http.StatusOK, res.StatusCode)
+			require.NoError(json.Unmarshal([]byte(body), &auditResponse))
+			require.Len(auditResponse.Entries, 1)
+			require.Equal("user.set_is_active", auditResponse.Entries[0].Action)
+			require.Equal("u4", auditResponse.Entries[0].TargetID)
+			require.NotEmpty(auditResponse.Entries[0].Before)
+			require.NotEmpty(auditResponse.Entries[0].After)
+		})
+	})
+	t.Run("G_Metrics", func(t *testing.T) {
+		res, body := tu.MakeRequest(t, url, "GET", "/metrics", nil)
+		require.Equal(http.StatusOK, res.StatusCode)
+
+		require.Contains(body, `http_requests_total{method="POST",route="/pullRequest/create",status="201"}`)
+		require.Contains(body, "http_request_duration_seconds_bucket")
+		require.Contains(body, "db_pool_total_connections")
+		require.Contains(body, `reviewer_open_pull_requests{team="mobile-devs"} 1`)
+		require.Contains(body, `reviewer_understaffed_pull_requests{team="backend-devs"} 0`)
+	})
+
+	t.Run("H_ErrorRequestID", func(t *testing.T) {
+		tu.Headers.Set("X-Request-ID", "req-h-1")
+		defer tu.Headers.Del("X-Request-ID")
+
+		res, body := tu.MakeRequest(t, url, "GET", "/team/get?team_name=unknown-team", nil)
+		require.Equal(http.StatusNotFound, res.StatusCode)
+		require.Equal("req-h-1", res.Header.Get("X-Request-ID"))
+
+		var errResponse ErrorResponse
+		require.NoError(json.Unmarshal([]byte(body), &errResponse))
+		require.Equal("NOT_FOUND", errResponse.Error.Code)
+		require.Equal("req-h-1", errResponse.Error.RequestID)
+	})
+	t.Run("I_IdempotencyKey", func(t *testing.T) {
+		prPayload := map[string]string{
+			"pull_request_id":   "pr-idem-1",
+			"pull_request_name": "Idempotent create",
+			"author_id":         "u1",
+		}
+
+		tu.Headers.Set("Idempotency-Key", "idem-create-1")
+		defer tu.Headers.Del("Idempotency-Key")
+
+		res, first := tu.MakeRequest(t, url, "POST", "/pullRequest/create", prPayload)
+		require.Equal(http.StatusCreated, res.StatusCode)
+
+		res, repeated := tu.MakeRequest(t, url, "POST", "/pullRequest/create", prPayload)
+		require.Equal(http.StatusCreated, res.StatusCode)
+		require.Equal("true", res.Header.Get("Idempotent-Replayed"))
+		require.Equal(first, repeated)
+
+		prPayload["pull_request_name"] = "Another body"
+		res, body := tu.MakeRequest(t, url, "POST", "/pullRequest/create", prPayload)
+		require.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+
+		var errResponse ErrorResponse
+		require.NoError(json.Unmarshal([]byte(body), &errResponse))
+		require.Equal("IDEMPOTENCY_KEY_REUSED", errResponse.Error.Code)
+	})
+	t.Run("J_PullRequestETag", func(t *testing.T) {
+		prPayload := map[string]string{
+			"pull_request_id":   "pr-etag-1",
+			"pull_request_name": "Versioned",
+			"author_id":         "u1",
+		}
+
+		res, body := tu.MakeRequest(t, url, "POST", "/pullRequest/create", prPayload)
+		require.Equal(http.StatusCreated, res.StatusCode)
+		require.Equal(`"1"`, res.Header.Get("ETag"))
+
+		require.NoError(json.Unmarshal([]byte(body), &prResponse))
+		require.Equal("OPEN", prResponse.PR.Status)
+
+		tu.Headers.Set("If-Match", `"1"`)
+		defer tu.Headers.Del("If-Match")
+
+		res, _ = tu.MakeRequest(t, url, "POST", "/pullRequest/merge", map[string]string{"pull_request_id": "pr-etag-1"})
+		require.Equal(http.StatusOK, res.StatusCode)
+		require.Equal(`"2"`, res.Header.Get("ETag"))
+
+		res, body = tu.MakeRequest(t, url, "POST", "/pullRequest/merge", map[string]string{"pull_request_id": "pr-etag-1"})
+		require.Equal(http.StatusPreconditionFailed, res.StatusCode)
+
+		var errResponse ErrorResponse
+		require.NoError(json.Unmarshal([]byte(body), &errResponse))
+		require.Equal("PRECONDITION_FAILED", errResponse.Error.Code)
+	})
+
+	t.Run("K_GraphQL", func(t *testing.T) {
+		var gqlResponse struct {
+			Data struct {
+				Team struct {
+					Name    string `json:"name"`
+					Members []struct {
+						ID      string `json:"id"`
+						Reviews []struct {
+							ID string `json:"id"`
+						} `json:"reviews"`
+					} `json:"members"`
+				} `json:"team"`
+				MergePullRequest struct {
+					Status string `json:"status"`
+				} `json:"mergePullRequest"`
+			} `json:"data"`
+			Errors []struct {
+				Extensions struct {
+					Code string `json:"code"`
+				} `json:"extensions"`
+			} `json:"errors"`
+		}
+
+		query := map[string]string{
+			"query": `{ team(name: "backend-devs") { name members { id reviews { id } } stats { users { userId } } } }`,
+		}
+
+		res, body := tu.MakeRequest(t, url, "POST", "/graphql", query)
+		require.Equal(http.StatusOK, res.StatusCode)
+		require.NoError(json.Unmarshal([]byte(body), &gqlResponse))
+		require.Empty(gqlResponse.Errors)
+		require.Equal("backend-devs", gqlResponse.Data.Team.Name)
+		require.Len(gqlResponse.Data.Team.Members, 4)
+
+		mutation := map[string]string{
+			"query": `mutation { mergePullRequest(id: "pr-etag-1", ifVersion: 1) { status } }`,
+		}
+
+		res, body = tu.MakeRequest(t, url, "POST", "/graphql", mutation)
+		require.Equal(http.StatusOK, res.StatusCode)
+		require.NoError(json.Unmarshal([]byte(body), &gqlResponse))
+		require.Len(gqlResponse.Errors, 1)
+		require.Equal("PRECONDITION_FAILED", gqlResponse.Errors[0].Extensions.Code)
+	})
+
+	t.Run("L_OpenAPIValidation", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"team_name": "no-members",
+		}
+
+		res, body := tu.MakeRequest(t, url, "POST", "/team/add", payload)
+		require.Equal(http.StatusBadRequest, res.StatusCode)
+
+		var errResp ErrorResponse
+		require.NoError(json.Unmarshal([]byte(body), &errResp))
+		require.Equal("VALIDATION_ERROR", errResp.Error.Code)
+		require.NotEmpty(errResp.Error.Fields)
+	})
+
+	t.Run("M_FieldValidation", func(t *testing.T) {
+		payload := map[string]interface{}{
+			"team_name": "dup-team",
+			"members": []map[string]interface{}{
+				{"user_id": "d1", "username": "Dup", "is_active": true},
+				{"user_id": "d1", "username": "Dup again", "is_active": true},
+			},
+		}
+
+		res, body := tu.MakeRequest(t, url, "POST", "/team/add", payload)
+		require.Equal(http.StatusBadRequest, res.StatusCode)
+
+		var errResp ErrorResponse
+		require.NoError(json.Unmarshal([]byte(body), &errResp))
+		require.Equal("VALIDATION_ERROR", errResp.Error.Code)
+		require.Len(errResp.Error.Fields, 1)
+		require.Equal("members.1.user_id", errResp.Error.Fields[0].Field)
+
+		res, _ = tu.MakeRequest(t, url, "POST", "/users/setIsActive", map[string]interface{}{
+			"user_id":   strings.Repeat("u", 101),
+			"is_active": true,
+		})
+		require.Equal(http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("N_ProblemDetails", func(t *testing.T) {
+		tu.Headers.Set("Accept", "application/problem+json")
+		defer tu.Headers.Del("Accept")
+
+		res, body := tu.MakeRequest(t, url, "GET", "/team/get?team_name=no-such-team", nil)
+		require.Equal(http.StatusNotFound, res.StatusCode)
+		require.Equal("application/problem+json", res.Header.Get("Content-Type"))
+
+		var problem struct {
+			Type   string `json:"type"`
+			Status int    `json:"status"`
+			Code   string `json:"code"`
+			Entity string `json:"entity"`
+		}
+
+		require.NoError(json.Unmarshal([]byte(body), &problem))
+		require.Equal("urn:avito-task:problem:not-found", problem.Type)
+		require.Equal(http.StatusNotFound, problem.Status)
+		require.Equal("NOT_FOUND", problem.Code)
+		require.Equal("team", problem.Entity)
+	})
+
+	t.Run("O_APIv2", func(t *testing.T) {
+		teamPayload := map[string]interface{}{
+			"name": "v2-team",
+			"members": []map[string]interface{}{
+				{"id": "v2-u1", "name": "Vera"},
+				{"id": "v2-u2", "name": "Vlad"},
+				{"id": "v2-u3", "name": "Vika"},
+			},
+		}
+
+		res, _ := tu.MakeRequest(t, url, "POST", "/v2/teams", teamPayload)
+		require.Equal(http.StatusCreated, res.StatusCode)
+		require.Equal("/v2/teams/v2-team", res.Header.Get("Location"))
+
+		var team struct {
+			Name    string `json:"name"`
+			Members []struct {
+				ID       string `json:"id"`
+				IsActive bool   `json:"is_active"`
+			} `json:"members"`
+		}
+
+		res, body := tu.MakeRequest(t, url, "GET", "/v2/teams/v2-team", nil)
+		require.Equal(http.StatusOK, res.StatusCode)
+		require.NoError(json.Unmarshal([]byte(body), &team))
+		require.Len(team.Members, 3)
+		require.True(team.Members[0].IsActive)
+
+		res, _ = tu.MakeRequest(t, url, "PATCH", "/v2/users/v2-u3", map[string]interface{}{"is_active": false})
+		require.Equal(http.StatusOK, res.StatusCode)
+
+		var pr struct {
+			ID        string   `json:"id"`
+			Status    string   `json:"status"`
+			Reviewers []string `json:"reviewers"`
+		}
+
+		prPayload := map[string]string{"id": "pr-v2-1", "name": "Resource API", "author_id": "v2-u1"}
+
+		res, body = tu.MakeRequest(t, url, "POST", "/v2/pull-requests", prPayload)
+		require.Equal(http.StatusCreated, res.StatusCode)
+		require.NoError(json.Unmarshal([]byte(body), &pr))
+		require.Equal([]string{"v2-u2"}, pr.Reviewers)
+
+		etag := res.Header.Get("ETag")
+		require.NotEmpty(etag)
+
+		tu.Headers.Set("If-Match", etag)
+		res, body = tu.MakeRequest(t, url, "POST", "/v2/pull-requests/pr-v2-1/merge", nil)
+		tu.Headers.Del("If-Match")
+
+		require.Equal(http.StatusOK, res.StatusCode)
+		require.NoError(json.Unmarshal([]byte(body), &pr))
+		require.Equal("MERGED", pr.Status)
+
+		res, _ = tu.MakeRequest(t, url, "GET", "/v2/users/no-such-user", nil)
+		require.Equal(http.StatusNotFound, res.StatusCode)
+	})
+
+	t.Run("P_Batch", func(t *testing.T) {
+		teamPayload := map[string]interface{}{
+			"team_name": "batch-team",
+			"members": []map[string]interface{}{
+				{"user_id": "b-u1", "username": "Boris", "is_active": true},
+				{"user_id": "b-u2", "username": "Bella", "is_active": true},
+			},
+		}
+
+		res, _ := tu.MakeRequest(t, url, "POST", "/team/add", teamPayload)
+		require.Equal(http.StatusCreated, res.StatusCode)
+
+		var batch struct {
+			Succeeded int `json:"succeeded"`
+			Failed    int `json:"failed"`
+			Results   []struct {
+				Status int `json:"status"`
+				Error  *struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			} `json:"results"`
+		}
+
+		payload := map[string]interface{}{
+			"atomic": true,
+			"operations": []map[string]interface{}{
+				{"op": "create_pr", "pull_request_id": "pr-b-1", "pull_request_name": "Batch", "author_id": "b-u1"},
+				{"op": "merge_pr", "pull_request_id": "pr-b-missing"},
+			},
+		}
+
+		res, body := tu.MakeRequest(t, url, "POST", "/batch", payload)
+		require.Equal(http.StatusOK, res.StatusCode)
+		require.NoError(json.Unmarshal([]byte(body), &batch))
+		require.Equal(2, batch.Failed)
+		require.Equal("BATCH_ABORTED", batch.Results[0].Error.Code)
+		require.Equal("NOT_FOUND", batch.Results[1].Error.Code)
+
+		res, _ = tu.MakeRequest(t, url, "POST", "/pullRequest/merge", map[string]string{"pull_request_id": "pr-b-1"})
+		require.Equal(http.StatusNotFound, res.StatusCode)
+
+		payload["atomic"] = false
+
+		res, body = tu.MakeRequest(t, url, "POST", "/batch", payload)
+		require.Equal(http.StatusOK, res.StatusCode)
+		require.NoError(json.Unmarshal([]byte(body), &batch))
+		require.Equal(1, batch.Succeeded)
+		require.Equal(http.StatusCreated, batch.Results[0].Status)
+		require.Equal(http.StatusNotFound, batch.Results[1].Status)
+
+		res, _ = tu.MakeRequest(t, url, "POST", "/batch", map[string]interface{}{
+			"operations": []map[string]interface{}{{"op": "merge_pr"}},
+		})
+		require.Equal(http.StatusBadRequest, res.StatusCode)
+	})
+
+	t.Run("Q_ImportPullRequests", func(t *testing.T) {
+		teamPayload := map[string]interface{}{
+			"team_name": "import-team",
+			"members": []map[string]interface{}{
+				{"user_id": "imp-u1", "username": "Irina", "is_active": true},
+				{"user_id": "imp-u2", "username": "Igor", "is_active": true},
+				{"user_id": "imp-u3", "username": "Ilya", "is_active": true},
+			},
+		}
+
+		res, _ := tu.MakeRequest(t, url, "POST", "/team/add", teamPayload)
+		require.Equal(http.StatusCreated, res.StatusCode)
+
+		csv := "pull_request_id,pull_request_name,author_id,status,reviewers,created_at,merged_at\n" +
+			"pr-imp-1,Legacy search,imp-u1,OPEN,imp-u3,2025-09-01T10:00:00Z,\n" +
+			"pr-imp-2,Legacy login,imp-u2,MERGED,imp-u1;imp-u3,2025-08-01T10:00:00Z,2025-08-02T10:00:00Z\n"
+
+		var report struct {
+			Applied bool `json:"applied"`
+			Invalid int  `json:"invalid"`
+			Items   []struct {
+				Status   string `json:"status"`
+				Problems []struct {
+					Field string `json:"field"`
+				} `json:"problems"`
+			} `json:"items"`
+		}
+
+		res, body := tu.MakeRawRequest(t, url, "POST", "/pullRequest/import?dry_run=true", "text/csv", csv)
+		require.Equal(http.StatusOK, res.StatusCode)
+		require.NoError(json.Unmarshal([]byte(body), &report))
+		require.False(report.Applied)
+		require.Equal("valid", report.Items[0].Status)
+
+		res, _ = tu.MakeRequest(t, url, "GET", "/users/getReview?user_id=imp-u3", nil)
+		require.Equal(http.StatusOK, res.StatusCode)
+
+		ndjson := `{"pull_request_id":"pr-imp-3","pull_request_name":"Broken","author_id":"imp-u9"}` + "\n"
+
+		res, body = tu.MakeRawRequest(t, url, "POST", "/pullRequest/import", "application/x-ndjson", ndjson)
+		require.Equal(http.StatusOK, res.StatusCode)
+		require.NoError(json.Unmarshal([]byte(body), &report))
+		require.False(report.Applied)
+		require.Equal(1, report.Invalid)
+		require.Equal("author_id", report.Items[0].Problems[0].Field)
+
+		res, body = tu.MakeRawRequest(t, url, "POST", "/pullRequest/import", "text/csv", csv)
+		require.Equal(http.StatusOK, res.StatusCode)
+		require.NoError(json.Unmarshal([]byte(body), &report))
+		require.True(report.Applied)
+		require.Equal("imported", report.Items[1].Status)
+
+		var reviews struct {
+			PullRequests []struct {
+				ID string `json:"pull_request_id"`
+			} `json:"pull_requests"`
+		}
+
+		res, body = tu.MakeRequest(t, url, "GET", "/users/getReview?user_id=imp-u3", nil)
+		require.Equal(http.StatusOK, res.StatusCode)
+		require.NoError(json.Unmarshal([]byte(body), &reviews))
+		require.Len(reviews.PullRequests, 2)
+	})
+
+	t.Run("R_Export", func(t *testing.T) {
+		res, body := tu.MakeRequest(t, url, "GET", "/export?team=import-team", nil)
+		require.Equal(http.StatusOK, res.StatusCode)
+		require.Equal("application/x-ndjson", res.Header.Get("Content-Type"))
+
+		counts := map[string]int{}
+
+		for _, line := range strings.Split(strings.TrimSpace(body), "\n") {
+			var row struct {
+				Entity string `json:"entity"`
+			}
+
+			require.NoError(json.Unmarshal([]byte(line), &row))
+			counts[row.Entity]++
+		}
+
+		require.Equal(map[string]int{"teams": 1, "users": 3, "pull_requests": 2, "reviews": 3}, counts)
+
+		res, body = tu.MakeRequest(t, url, "GET", "/export?format=csv&entity=pull_requests&team=import-team", nil)
+		require.Equal(http.StatusOK, res.StatusCode)
+		rows, err := csv.NewReader(strings.NewReader(body)).ReadAll()
+		require.NoError(err)
+		require.Len(rows, 3)
+		require.Equal([]string{"pull_request_id", "pull_request_name", "author_id", "status",
+			"created_at", "merged_at", "version", "updated_at"}, rows[0])
+		require.Equal([]string{"pr-imp-2", "Legacy login", "imp-u2", "MERGED",
+			"2025-08-01T10:00:00Z", "2025-08-02T10:00:00Z"}, rows[2][:6])
+
+		res, _ = tu.MakeRequest(t, url, "GET", "/export?format=csv", nil)
+		require.Equal(http.StatusBadRequest, res.StatusCode)
+
+		res, _ = tu.MakeRequest(t, url, "GET", "/export?team=no-such-team", nil)
+		require.Equal(http.StatusNotFound, res.StatusCode)
+	})
 }